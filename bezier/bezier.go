@@ -0,0 +1,217 @@
+// Package bezier implements parsing of bicubic Bezier patch files such as
+// the classic Utah (Newell) teapot.
+//
+// Two formats are supported:
+//
+// The .bpt format starts with the number of patches, and each patch
+// starts with its degree in u and v (which must be "3 3") followed by its
+// 16 control points, one "x y z" triple per line.
+//
+// The Newell format starts with the number of patches followed by one line
+// of 16 comma-separated (1-based) vertex indices per patch, then the number
+// of vertices followed by one line of comma-separated "x,y,z" coordinates
+// per vertex.
+//
+// Both formats traditionally use Z as the up axis.
+package bezier
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gmlewis/rtc/rtc"
+)
+
+// File represents a parsed Bezier patch file.
+type File struct {
+	Patches []*rtc.BezierPatchT
+}
+
+// ToGroup returns a GroupT containing all the patches in the file.
+func (f *File) ToGroup() *rtc.GroupT {
+	g := rtc.Group()
+	for _, p := range f.Patches {
+		g.AddChild(p)
+	}
+	return g
+}
+
+// ParseBPTFile parses a .bpt file and returns a File.
+func ParseBPTFile(filename string) (*File, error) {
+	return parseFile(filename, ParseBPT)
+}
+
+// ParseNewellFile parses a Newell format file and returns a File.
+func ParseNewellFile(filename string) (*File, error) {
+	return parseFile(filename, ParseNewell)
+}
+
+func parseFile(filename string, parse func(r io.Reader) (*File, error)) (*File, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parse(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// ParseBPT parses patches in the .bpt format and returns a File.
+func ParseBPT(r io.Reader) (*File, error) {
+	s := newScanner(r)
+
+	numPatches, err := s.nextInt()
+	if err != nil {
+		return nil, fmt.Errorf("patch count: %v", err)
+	}
+
+	f := &File{}
+	for i := 0; i < numPatches; i++ {
+		uDegree, err := s.nextInt()
+		if err != nil {
+			return nil, fmt.Errorf("patch %v degree: %v", i, err)
+		}
+		vDegree, err := s.nextInt()
+		if err != nil {
+			return nil, fmt.Errorf("patch %v degree: %v", i, err)
+		}
+		if uDegree != 3 || vDegree != 3 {
+			return nil, fmt.Errorf("patch %v has degree %vx%v, only bicubic (3x3) patches are supported", i, uDegree, vDegree)
+		}
+
+		var points [16]rtc.Tuple
+		for j := range points {
+			if points[j], err = s.nextPoint(); err != nil {
+				return nil, fmt.Errorf("patch %v point %v: %v", i, j, err)
+			}
+		}
+		f.Patches = append(f.Patches, rtc.BezierPatch(points))
+	}
+
+	return f, nil
+}
+
+// ParseNewell parses patches in the Newell format and returns a File.
+func ParseNewell(r io.Reader) (*File, error) {
+	s := newScanner(r)
+
+	numPatches, err := s.nextInt()
+	if err != nil {
+		return nil, fmt.Errorf("patch count: %v", err)
+	}
+
+	indices := make([][16]int, numPatches)
+	for i := range indices {
+		for j := range indices[i] {
+			if indices[i][j], err = s.nextInt(); err != nil {
+				return nil, fmt.Errorf("patch %v index %v: %v", i, j, err)
+			}
+		}
+	}
+
+	numVertices, err := s.nextInt()
+	if err != nil {
+		return nil, fmt.Errorf("vertex count: %v", err)
+	}
+
+	vertices := make([]rtc.Tuple, numVertices)
+	for i := range vertices {
+		if vertices[i], err = s.nextPoint(); err != nil {
+			return nil, fmt.Errorf("vertex %v: %v", i+1, err)
+		}
+	}
+
+	f := &File{}
+	for i, patch := range indices {
+		var points [16]rtc.Tuple
+		for j, index := range patch {
+			if index < 1 || index > numVertices {
+				return nil, fmt.Errorf("patch %v index %v: vertex %v out of range [1,%v]", i, j, index, numVertices)
+			}
+			points[j] = vertices[index-1]
+		}
+		f.Patches = append(f.Patches, rtc.BezierPatch(points))
+	}
+
+	return f, nil
+}
+
+// scanner returns the numbers in the input separated by whitespace
+// or commas.
+type scanner struct {
+	s *bufio.Scanner
+}
+
+func newScanner(r io.Reader) *scanner {
+	s := bufio.NewScanner(r)
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		isSep := func(b byte) bool {
+			return b == ',' || b == ' ' || b == '\t' || b == '\n' || b == '\r'
+		}
+		start := 0
+		for start < len(data) && isSep(data[start]) {
+			start++
+		}
+		for i := start; i < len(data); i++ {
+			if isSep(data[i]) {
+				return i + 1, data[start:i], nil
+			}
+		}
+		if atEOF && len(data) > start {
+			return len(data), data[start:], nil
+		}
+		return start, nil, nil
+	})
+	return &scanner{s: s}
+}
+
+func (s *scanner) next() (string, error) {
+	if !s.s.Scan() {
+		if err := s.s.Err(); err != nil {
+			return "", err
+		}
+		return "", io.ErrUnexpectedEOF
+	}
+	return s.s.Text(), nil
+}
+
+func (s *scanner) nextInt() (int, error) {
+	token, err := s.next()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(token))
+}
+
+func (s *scanner) nextFloat() (float64, error) {
+	token, err := s.next()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(token), 64)
+}
+
+func (s *scanner) nextPoint() (rtc.Tuple, error) {
+	var xyz [3]float64
+	for i := range xyz {
+		v, err := s.nextFloat()
+		if err != nil {
+			return rtc.Tuple{}, err
+		}
+		xyz[i] = v
+	}
+	return rtc.Point(xyz[0], xyz[1], xyz[2]), nil
+}
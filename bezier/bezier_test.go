@@ -0,0 +1,142 @@
+package bezier
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gmlewis/rtc/rtc"
+)
+
+const flatBPT = `1
+3 3
+0 0 0
+1 0 0
+2 0 0
+3 0 0
+0 1 0
+1 1 0
+2 1 0
+3 1 0
+0 2 0
+1 2 0
+2 2 0
+3 2 0
+0 3 0
+1 3 0
+2 3 0
+3 3 1
+`
+
+func TestParseBPT(t *testing.T) {
+	f, err := ParseBPT(bytes.NewBufferString(flatBPT))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(f.Patches), 1; got != want {
+		t.Fatalf("len(f.Patches) = %v, want %v", got, want)
+	}
+
+	p := f.Patches[0]
+	if got, want := p.Points[1], rtc.Point(1, 0, 0); !got.Equal(want) {
+		t.Errorf("Points[1] = %v, want %v", got, want)
+	}
+	if got, want := p.Points[15], rtc.Point(3, 3, 1); !got.Equal(want) {
+		t.Errorf("Points[15] = %v, want %v", got, want)
+	}
+}
+
+func TestParseNewell(t *testing.T) {
+	const src = `2
+1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16
+16,15,14,13,12,11,10,9,8,7,6,5,4,3,2,1
+16
+0.0,0.0,0.0
+1.0,0.0,0.0
+2.0,0.0,0.0
+3.0,0.0,0.0
+0.0,1.0,0.0
+1.0,1.0,0.0
+2.0,1.0,0.0
+3.0,1.0,0.0
+0.0,2.0,0.0
+1.0,2.0,0.0
+2.0,2.0,0.0
+3.0,2.0,0.0
+0.0,3.0,0.0
+1.0,3.0,0.0
+2.0,3.0,0.0
+3.0,3.0,1.5
+`
+
+	f, err := ParseNewell(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(f.Patches), 2; got != want {
+		t.Fatalf("len(f.Patches) = %v, want %v", got, want)
+	}
+
+	if got, want := f.Patches[0].Points[15], rtc.Point(3, 3, 1.5); !got.Equal(want) {
+		t.Errorf("Patches[0].Points[15] = %v, want %v", got, want)
+	}
+	if got, want := f.Patches[1].Points[0], rtc.Point(3, 3, 1.5); !got.Equal(want) {
+		t.Errorf("Patches[1].Points[0] = %v, want %v", got, want)
+	}
+
+	g := f.ToGroup()
+	if got, want := len(g.Children), 2; got != want {
+		t.Fatalf("len(g.Children) = %v, want %v", got, want)
+	}
+	if got, want := g.Bounds().Max, rtc.Point(3, 3, 1.5); !got.Equal(want) {
+		t.Errorf("g.Bounds.Max = %v, want %v", got, want)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(src string) error
+		src   string
+	}{
+		{
+			name:  "bpt with unsupported degree",
+			parse: parseBPTString,
+			src:   "1\n2 2\n0 0 0\n",
+		},
+		{
+			name:  "bpt truncated",
+			parse: parseBPTString,
+			src:   "1\n3 3\n0 0 0\n1 0 0\n",
+		},
+		{
+			name:  "newell index out of range",
+			parse: parseNewellString,
+			src:   "1\n1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,17\n1\n0,0,0\n",
+		},
+		{
+			name:  "newell gibberish",
+			parse: parseNewellString,
+			src:   "There was a young lady named Bright\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.parse(tt.src); err == nil {
+				t.Errorf("parse = nil error, want error")
+			}
+		})
+	}
+}
+
+func parseBPTString(src string) error {
+	_, err := ParseBPT(bytes.NewBufferString(src))
+	return err
+}
+
+func parseNewellString(src string) error {
+	_, err := ParseNewell(bytes.NewBufferString(src))
+	return err
+}
@@ -19,6 +19,7 @@ var (
 	yTranslate = flag.Float64("ty", 0, "Y translate object")
 	xRotate    = flag.Float64("rx", 0, "X rotate object (in degrees)")
 	yRotate    = flag.Float64("ry", 180, "Y rotate object (in degrees)")
+	useGroups  = flag.Bool("groups", false, "Load each file as a group of individual triangles instead of a single indexed mesh")
 
 	pngFile = flag.String("png", "test-obj.png", "Output PNG file")
 	ppmFile = flag.String("ppm", "test-obj.ppm", "Output PPM file")
@@ -30,18 +31,14 @@ func main() {
 	world := genWorld()
 
 	for _, arg := range flag.Args() {
-		obj, err := obj.ParseObjFile(arg)
-		if err != nil {
-			log.Fatal(err)
-		}
+		g, ignoredLines := load(arg)
 
 		toRad := func(deg float64) float64 {
 			return deg * math.Pi / 180
 		}
 
-		g := obj.ToGroup()
 		b := g.Bounds()
-		log.Printf("Processed file %q, %v lines ignored. Bounds: %v", arg, obj.IgnoredLines, b)
+		log.Printf("Processed file %q, %v lines ignored. Bounds: %v", arg, ignoredLines, b)
 
 		if *autoFit {
 			tx := -0.5 * (b.Min.X() + b.Max.X())
@@ -86,6 +83,24 @@ func main() {
 	}
 }
 
+// load parses the OBJ file either as a single indexed mesh or as a group
+// of individual triangles.
+func load(filename string) (rtc.Object, int) {
+	if *useGroups {
+		o, err := obj.ParseObjFile(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return o.ToGroup(), o.IgnoredLines
+	}
+
+	m, err := obj.ParseObjMeshFile(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return m.Mesh, m.IgnoredLines
+}
+
 func genWorld() *rtc.WorldT {
 	w := rtc.World()
 
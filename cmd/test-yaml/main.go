@@ -14,6 +14,24 @@ var (
 	xsize = flag.Int("xsize", 128, "X size")
 	ysize = flag.Int("ysize", 102, "Y size")
 
+	projection = flag.String("projection", "", "Camera projection (perspective, orthographic, fisheye or equirectangular)")
+	orthoWidth = flag.Float64("ortho-width", 0, "Width of the orthographic view in world units (0 keeps the camera's)")
+
+	ipd         = flag.Float64("ipd", 0, "Interpupillary distance for stereo rendering (0 renders a single view)")
+	convergence = flag.Float64("convergence", 0, "Stereo convergence distance (0 uses parallel views)")
+	topBottom   = flag.Bool("top-bottom", false, "Stack stereo views vertically instead of side by side")
+
+	hdri          = flag.String("hdri", "", "Latitude-longitude HDR image that surrounds and lights the scene")
+	hdriSamples   = flag.Int("hdri-samples", 8, "Image light samples per shading point along each axis")
+	hdriIntensity = flag.Float64("hdri-intensity", 1, "Intensity of the HDR image")
+
+	aoSamples  = flag.Int("ao-samples", 0, "Ambient occlusion samples per shading point (0 disables ambient occlusion)")
+	aoDistance = flag.Float64("ao-distance", 0, "Maximum distance of ambient occluders (0 is unlimited)")
+	aoOnly     = flag.Bool("ao-only", false, "Render grayscale ambient occlusion instead of the shaded scene")
+
+	aovPrefix = flag.String("aov", "", "Also write each render pass (depth, normal, albedo, ...) to <prefix>-<pass>.png")
+	denoise   = flag.Bool("denoise", false, "Denoise the render guided by its normal, albedo and depth passes")
+
 	pngFile = flag.String("png", "test-yaml.png", "Output PNG file")
 	ppmFile = flag.String("ppm", "test-yaml.ppm", "Output PPM file")
 )
@@ -40,7 +58,62 @@ func main() {
 		}
 	}
 
-	canvas := camera.Render(world)
+	if *hdri != "" {
+		image, err := rtc.ReadCanvasFile(*hdri)
+		if err != nil {
+			log.Fatal(err)
+		}
+		env := rtc.EquirectangularEnvironment(image)
+		env.Intensity = *hdriIntensity
+		light := rtc.ImageLight(image, *hdriSamples, *hdriSamples)
+		light.Intensity = *hdriIntensity
+		world.Environment = env
+		world.ImageLights = append(world.ImageLights, light)
+	}
+
+	if *aoSamples > 0 {
+		world.AmbientOcclusion = rtc.AmbientOcclusion(*aoSamples, *aoDistance)
+	}
+
+	if *projection != "" {
+		p, err := rtc.ParseProjection(*projection)
+		if err != nil {
+			log.Fatal(err)
+		}
+		camera.Projection = p
+	}
+	if *orthoWidth > 0 {
+		camera.OrthographicWidth = *orthoWidth
+	}
+
+	var canvas *rtc.Canvas
+	switch {
+	case *aoOnly:
+		ao := world.AmbientOcclusion
+		if ao == nil {
+			ao = rtc.AmbientOcclusion(16, *aoDistance)
+		}
+		canvas = camera.RenderAmbientOcclusion(world, ao)
+	case *ipd > 0:
+		stereo := rtc.StereoCamera(camera, *ipd, *convergence)
+		if *topBottom {
+			stereo.Layout = rtc.TopBottom
+		}
+		canvas = stereo.Render(world)
+	case *aovPrefix != "" || *denoise:
+		passes := camera.RenderPasses(world)
+		if *aovPrefix != "" {
+			if err := passes.WritePNGFiles(*aovPrefix); err != nil {
+				log.Fatal(err)
+			}
+		}
+		canvas = passes.Pass(rtc.BeautyPass)
+		if *denoise {
+			canvas = passes.Denoise(rtc.Denoiser())
+		}
+	default:
+		canvas = camera.Render(world)
+	}
 
 	if *pngFile != "" {
 		if err := canvas.WritePNGFile(*pngFile); err != nil {
@@ -0,0 +1,111 @@
+package obj
+
+import (
+	"io"
+	"os"
+
+	"github.com/gmlewis/rtc/rtc"
+)
+
+// ObjMesh represents a Wavefront OBJ file parsed into a single indexed
+// mesh, which uses far less memory than one object per face.
+type ObjMesh struct {
+	Mesh         *rtc.MeshT
+	IgnoredLines int
+
+	// MaterialNames holds the names from "usemtl" statements in the order
+	// of the per-face materials of the mesh (see rtc.MeshT.FaceMaterial).
+	MaterialNames []string
+}
+
+// ParseObjMeshFile parses a Wavefront OBJ file and returns an ObjMesh.
+func ParseObjMeshFile(filename string) (*ObjMesh, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	mesh, err := ParseObjMesh(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return mesh, nil
+}
+
+// ParseObjMesh parses a Wavefront OBJ and returns an ObjMesh.
+// Polygons are split into triangle fans, faces with vertex normals are
+// smooth, and groups are merged into the single mesh.
+func ParseObjMesh(r io.Reader) (*ObjMesh, error) {
+	b := &meshBuilder{
+		result:    &ObjMesh{},
+		materials: map[string]int32{},
+		material:  -1,
+	}
+	if err := readObj(r, b); err != nil {
+		return nil, err
+	}
+
+	meshMaterials := make([]rtc.MaterialT, len(b.result.MaterialNames))
+	for i := range meshMaterials {
+		meshMaterials[i] = rtc.GetMaterial()
+	}
+	b.result.Mesh = rtc.Mesh(b.vertices, b.normals, b.texCoords, b.faces, meshMaterials...)
+	return b.result, nil
+}
+
+// meshBuilder collects the vertices, normals, texture coordinates, and
+// faces of an ObjMesh.
+type meshBuilder struct {
+	result                       *ObjMesh
+	vertices, normals, texCoords []rtc.Tuple
+	faces                        []rtc.MeshFace
+
+	materials map[string]int32
+	material  int32
+}
+
+func (b *meshBuilder) vertex(v rtc.Tuple)   { b.vertices = append(b.vertices, v) }
+func (b *meshBuilder) normal(n rtc.Tuple)   { b.normals = append(b.normals, n) }
+func (b *meshBuilder) texCoord(t rtc.Tuple) { b.texCoords = append(b.texCoords, t) }
+func (b *meshBuilder) group(name string)    {} // groups are merged into the mesh
+func (b *meshBuilder) ignore()              { b.result.IgnoredLines++ }
+
+// face adds a fan of triangles with the current material. A triangle is
+// only smooth (or textured) if all of the polygon's vertices are.
+func (b *meshBuilder) face(polygon []objVertex) {
+	smooth, textured := true, true
+	for _, v := range polygon {
+		smooth = smooth && v.normal >= 0
+		textured = textured && v.texCoord >= 0
+	}
+
+	for i := 2; i < len(polygon); i++ {
+		v1, v2, v3 := polygon[0], polygon[i-1], polygon[i]
+		f := rtc.MeshTriangle(int(v1.vertex), int(v2.vertex), int(v3.vertex))
+		if smooth {
+			f.Normals = [3]int32{v1.normal, v2.normal, v3.normal}
+		}
+		if textured {
+			f.TexCoords = [3]int32{v1.texCoord, v2.texCoord, v3.texCoord}
+		}
+		f.Material = b.material
+		b.faces = append(b.faces, f)
+	}
+}
+
+// useMaterial assigns the named per-face material to the following faces.
+func (b *meshBuilder) useMaterial(name string) {
+	index, ok := b.materials[name]
+	if !ok {
+		index = int32(len(b.result.MaterialNames))
+		b.materials[name] = index
+		b.result.MaterialNames = append(b.result.MaterialNames, name)
+	}
+	b.material = index
+}
@@ -0,0 +1,107 @@
+package obj
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gmlewis/rtc/rtc"
+)
+
+func TestParseObjMesh(t *testing.T) {
+	fileData := `
+# A square made of a polygon, a smooth triangle, and a textured triangle.
+mtllib scene.mtl
+v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+vn 0 0 -1
+vt 0 0
+vt 1 0
+vt 0.5 1
+
+g Polygons
+f 1 2 3 4
+usemtl red
+f 1//1 3//1 5//1
+usemtl blue
+f 2/1 3/2 -1/3
+usemtl red
+f -5 -4 -3
+`
+	m, err := ParseObjMesh(bytes.NewBufferString(fileData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := m.IgnoredLines, 2; got != want {
+		t.Errorf("IgnoredLines = %v, want %v", got, want)
+	}
+	if got, want := m.MaterialNames, []string{"red", "blue"}; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("MaterialNames = %v, want %v", got, want)
+	}
+
+	mesh := m.Mesh
+	if got, want := len(mesh.Vertices), 5; got != want {
+		t.Errorf("len(Vertices) = %v, want %v", got, want)
+	}
+	if got, want := len(mesh.Normals), 1; got != want {
+		t.Errorf("len(Normals) = %v, want %v", got, want)
+	}
+	if got, want := len(mesh.TexCoords), 3; got != want {
+		t.Errorf("len(TexCoords) = %v, want %v", got, want)
+	}
+	if got, want := mesh.NumFaceMaterials(), 2; got != want {
+		t.Errorf("NumFaceMaterials = %v, want %v", got, want)
+	}
+
+	// Faces are reordered by the BVH, so match them in any order.
+	red := rtc.MeshTriangle(0, 1, 2)
+	red.Material = 0
+	want := []rtc.MeshFace{
+		rtc.MeshTriangle(0, 1, 2),
+		rtc.MeshTriangle(0, 2, 3),
+		{Vertices: [3]int32{0, 2, 4}, Normals: [3]int32{0, 0, 0}, TexCoords: [3]int32{-1, -1, -1}, Material: 0},
+		{Vertices: [3]int32{1, 2, 4}, Normals: [3]int32{-1, -1, -1}, TexCoords: [3]int32{0, 1, 2}, Material: 1},
+		red,
+	}
+	got := append([]rtc.MeshFace(nil), mesh.Faces...)
+	if len(got) != len(want) {
+		t.Fatalf("Faces = %+v, want %+v", got, want)
+	}
+	for _, w := range want {
+		found := false
+		for i, f := range got {
+			if f == w {
+				got = append(got[:i], got[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("face %+v not found in %+v", w, mesh.Faces)
+		}
+	}
+}
+
+func TestParseObjMesh_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileData string
+	}{
+		{name: "vertex index out of range", fileData: "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 4\n"},
+		{name: "normal index out of range", fileData: "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1//1 2//1 3//1\n"},
+		{name: "too few face vertices", fileData: "v 0 0 0\nv 1 0 0\nf 1 2\n"},
+		{name: "bad vertex", fileData: "v 0 zero 0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseObjMesh(bytes.NewBufferString(tt.fileData)); err == nil {
+				t.Errorf("ParseObjMesh = nil error, want error")
+			}
+		})
+	}
+}
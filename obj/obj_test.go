@@ -343,6 +343,31 @@ func TestParseObj_NamedGroups(t *testing.T) {
 	}
 }
 
+func TestParseObj_UnnamedGroup(t *testing.T) {
+	const src = `v -1 1 0
+v -1 0 0
+v 1 0 0
+g FirstGroup
+f 1 2 3
+g
+f 1 2 3
+`
+	obj, err := ParseObj(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := obj.IgnoredLines, 1; got != want {
+		t.Errorf("obj.IgnoredLines = %v, want %v", got, want)
+	}
+	if got, want := len(obj.NamedGroups), 1; got != want {
+		t.Fatalf("len(obj.NamedGroups) = %v, want %v", got, want)
+	}
+	if got, want := len(obj.NamedGroups["FirstGroup"].Children), 2; got != want {
+		t.Errorf("len(FirstGroup.Children) = %v, want %v", got, want)
+	}
+}
+
 func TestParseObj_VertexNormals(t *testing.T) {
 	vertices := `
 vn 0 0 1
@@ -473,3 +498,51 @@ f 1/0/3 2/102/1 3/14/2
 		t.Errorf("t2.N3 = %v, want %v", got, want)
 	}
 }
+
+func TestParseObj_TexturedFaces(t *testing.T) {
+	fileData := `
+v 0 1 0
+v -1 0 0
+v 1 0 0
+vt 0 0
+vt 1 0
+vt 0 1
+usemtl red
+f 1/1 2/2 3/3
+`
+	obj, err := ParseObj(bytes.NewBufferString(fileData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Triangles do not support texture coordinates or materials.
+	if got, want := obj.IgnoredLines, 4; got != want {
+		t.Errorf("obj.IgnoredLines = %v, want %v", got, want)
+	}
+	if got, want := len(obj.DefaultGroup.Children), 1; got != want {
+		t.Fatalf("len(obj.DefaultGroup.Children) = %v, want %v", got, want)
+	}
+	if _, ok := obj.DefaultGroup.Children[0].(*rtc.TriangleT); !ok {
+		t.Errorf("obj.DefaultGroup.Children[0] = %T, want *TriangleT", obj.DefaultGroup.Children[0])
+	}
+}
+
+func TestParseObj_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileData string
+	}{
+		{name: "vertex index out of range", fileData: "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 4\n"},
+		{name: "normal index out of range", fileData: "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1//1 2//1 3//1\n"},
+		{name: "too few face vertices", fileData: "v 0 0 0\nv 1 0 0\nf 1 2\n"},
+		{name: "bad vertex", fileData: "v 0 zero 0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseObj(bytes.NewBufferString(tt.fileData)); err == nil {
+				t.Errorf("ParseObj = nil error, want error")
+			}
+		})
+	}
+}
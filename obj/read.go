@@ -0,0 +1,180 @@
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gmlewis/rtc/rtc"
+)
+
+// objVertex is a vertex of a face with (0-based) indices into the
+// vertices, texture coordinates, and normals read so far, where -1 means
+// that the face has no texture coordinate or normal at that vertex.
+type objVertex struct {
+	vertex, texCoord, normal int32
+}
+
+// objBuilder builds a result from the statements of a Wavefront OBJ file
+// as readObj reads them.
+type objBuilder interface {
+	vertex(v rtc.Tuple)
+	normal(n rtc.Tuple)
+	texCoord(t rtc.Tuple)
+	// face receives a polygon of 3 or more vertices.
+	face(polygon []objVertex)
+	group(name string)
+	useMaterial(name string)
+	// ignore is called for each unsupported (or comment) line.
+	ignore()
+}
+
+// readObj reads a Wavefront OBJ and passes its statements to the builder.
+// Errors report the (1-based) line number.
+func readObj(r io.Reader, builder objBuilder) error {
+	var numVertices, numNormals, numTexCoords int
+
+	b := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := b.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			var parseErr error
+			switch fields[0] {
+			case "v":
+				var v [3]float64
+				if v, parseErr = parse3Floats(fields[1:]); parseErr == nil {
+					builder.vertex(rtc.Point(v[0], v[1], v[2]))
+					numVertices++
+				}
+			case "vn":
+				var v [3]float64
+				if v, parseErr = parse3Floats(fields[1:]); parseErr == nil {
+					builder.normal(rtc.Vector(v[0], v[1], v[2]))
+					numNormals++
+				}
+			case "vt":
+				var u, v float64
+				if len(fields) < 2 {
+					parseErr = fmt.Errorf("expect texture coordinates, got %q", line)
+					break
+				}
+				if u, parseErr = strconv.ParseFloat(fields[1], 64); parseErr != nil {
+					break
+				}
+				if len(fields) > 2 {
+					if v, parseErr = strconv.ParseFloat(fields[2], 64); parseErr != nil {
+						break
+					}
+				}
+				builder.texCoord(rtc.Point(u, v, 0))
+				numTexCoords++
+			case "f":
+				var polygon []objVertex
+				if polygon, parseErr = parseFace(fields[1:], numVertices, numNormals, numTexCoords); parseErr == nil {
+					builder.face(polygon)
+				}
+			case "g":
+				if len(fields) < 2 {
+					builder.ignore() // a "g" without a name does not start a group
+					break
+				}
+				builder.group(strings.Join(fields[1:], " "))
+			case "usemtl":
+				if len(fields) < 2 {
+					parseErr = fmt.Errorf("expect material name, got %q", line)
+					break
+				}
+				builder.useMaterial(fields[1])
+			default:
+				builder.ignore()
+			}
+			if parseErr != nil {
+				return fmt.Errorf("line %v: %v", lineNum, parseErr)
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+func parse3Floats(args []string) ([3]float64, error) {
+	var v [3]float64
+	if len(args) < 3 {
+		return v, fmt.Errorf("expect 3 floats, got %q", args)
+	}
+	for i := range v {
+		f, err := strconv.ParseFloat(args[i], 64)
+		if err != nil {
+			return v, err
+		}
+		v[i] = f
+	}
+	return v, nil
+}
+
+// parseFace parses the vertices of a polygon. Each argument is "v",
+// "v/vt", "v//vn", or "v/vt/vn" with 1-based (or negative, relative)
+// indices that are converted to 0-based indices. Texture coordinate
+// indices are ignored in files without texture coordinates.
+func parseFace(args []string, numVertices, numNormals, numTexCoords int) ([]objVertex, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("expect 3 or more face arguments, got %q", args)
+	}
+
+	resolve := func(s string, count int, kind string) (int32, error) {
+		if s == "" {
+			return -1, nil
+		}
+		index, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, err
+		}
+		if index < 0 {
+			index += count + 1
+		}
+		if index < 1 || index > count {
+			return 0, fmt.Errorf("%v index %v out of range [1,%v]", kind, s, count)
+		}
+		return int32(index - 1), nil
+	}
+
+	var polygon []objVertex
+	for _, arg := range args {
+		parts := strings.Split(arg, "/")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid face argument %q", arg)
+		}
+		for len(parts) < 3 {
+			parts = append(parts, "")
+		}
+
+		vi, err := resolve(parts[0], numVertices, "vertex")
+		if err != nil {
+			return nil, err
+		}
+		if vi < 0 {
+			return nil, fmt.Errorf("missing vertex index in %q", arg)
+		}
+		ti := int32(-1)
+		if numTexCoords > 0 {
+			if ti, err = resolve(parts[1], numTexCoords, "texture coordinate"); err != nil {
+				return nil, err
+			}
+		}
+		ni, err := resolve(parts[2], numNormals, "normal")
+		if err != nil {
+			return nil, err
+		}
+		polygon = append(polygon, objVertex{vertex: vi, texCoord: ti, normal: ni})
+	}
+	return polygon, nil
+}
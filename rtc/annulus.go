@@ -0,0 +1,97 @@
+package rtc
+
+import "math"
+
+// Annulus creates a flat ring at the origin on the X-Z axes and +Y is up,
+// with an inner radius of 0.5 and an outer radius of 1.
+// It implements the Object interface.
+func Annulus() *AnnulusT {
+	return &AnnulusT{
+		Shape:       Shape{Transform: M4Identity(), Material: GetMaterial()},
+		InnerRadius: 0.5,
+		OuterRadius: 1,
+	}
+}
+
+// AnnulusT represents an Annulus.
+type AnnulusT struct {
+	Shape
+	InnerRadius float64
+	OuterRadius float64
+}
+
+var _ Object = &AnnulusT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (a *AnnulusT) SetTransform(m M4) Object {
+	a.Transform = m
+	return a
+}
+
+// SetMaterial sets the object's material.
+func (a *AnnulusT) SetMaterial(material MaterialT) Object {
+	a.setMaterial(material)
+	return a
+}
+
+// SetParent sets the object's parent object.
+func (a *AnnulusT) SetParent(parent Object) Object {
+	a.Parent = parent
+	return a
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (a *AnnulusT) Bounds() *BoundsT {
+	return &BoundsT{
+		Min: Point(-a.OuterRadius, 0, -a.OuterRadius),
+		Max: Point(a.OuterRadius, 0, a.OuterRadius),
+	}
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+// The U value is the angle around the Y axis (in the range [0,1)) and
+// the V value is the relative distance from the inner to the outer radius.
+func (a *AnnulusT) LocalIntersect(ray RayT) []IntersectionT {
+	if math.Abs(ray.Direction.Y()) < epsilon {
+		return nil
+	}
+
+	t := -ray.Origin.Y() / ray.Direction.Y()
+	x := ray.Origin.X() + t*ray.Direction.X()
+	z := ray.Origin.Z() + t*ray.Direction.Z()
+	dist := math.Sqrt(x*x + z*z)
+	if dist < a.InnerRadius || dist > a.OuterRadius {
+		return nil
+	}
+
+	v := (dist - a.InnerRadius) / (a.OuterRadius - a.InnerRadius)
+	return []IntersectionT{IntersectionWithUV(t, a, planarAngle(x, z), v)}
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+func (a *AnnulusT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	return Vector(0, 1, 0)
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (a *AnnulusT) Includes(other Object) bool {
+	return a == other
+}
+
+// SampleSurface maps the provided (u,v) values uniformly to a point on
+// the annulus.
+func (a *AnnulusT) SampleSurface(u, v float64) (Tuple, Object) {
+	r2 := a.InnerRadius*a.InnerRadius + v*(a.OuterRadius*a.OuterRadius-a.InnerRadius*a.InnerRadius)
+	r := math.Sqrt(r2)
+	phi := 2 * math.Pi * u
+	return Point(r*math.Cos(phi), 0, r*math.Sin(phi)), a
+}
+
+// SurfaceArea returns the area of the annulus.
+func (a *AnnulusT) SurfaceArea() float64 {
+	return math.Pi * (a.OuterRadius*a.OuterRadius - a.InnerRadius*a.InnerRadius)
+}
@@ -0,0 +1,86 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnnulusT_LocalIntersect(t *testing.T) {
+	a := Annulus()
+
+	tests := []struct {
+		name  string
+		ray   RayT
+		want  []IntersectionT
+		wantU float64
+		wantV float64
+	}{
+		{
+			name: "A ray through the hole misses",
+			ray:  Ray(Point(0, 1, 0.25), Vector(0, -1, 0)),
+		},
+		{
+			name:  "A ray through the middle of the ring",
+			ray:   Ray(Point(-0.75, 1, 0), Vector(0, -1, 0)),
+			want:  []IntersectionT{Intersection(1, a)},
+			wantU: 0.5,
+			wantV: 0.5,
+		},
+		{
+			name:  "A ray at the outer edge",
+			ray:   Ray(Point(0, -2, -1), Vector(0, 1, 0)),
+			want:  []IntersectionT{Intersection(2, a)},
+			wantU: 0.75,
+			wantV: 1,
+		},
+		{
+			name: "A ray outside the ring misses",
+			ray:  Ray(Point(1, 1, 1), Vector(0, -1, 0)),
+		},
+		{
+			name: "A ray parallel to the ring misses",
+			ray:  Ray(Point(-5, 0, 0.75), Vector(1, 0, 0)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := a.LocalIntersect(tt.ray)
+			if len(got) != len(tt.want) {
+				t.Fatalf("LocalIntersect = %v, want %v", got, tt.want)
+			}
+			for i, w := range tt.want {
+				if got[i].T != w.T || got[i].Object != w.Object {
+					t.Errorf("LocalIntersect[%v] = %v, want %v", i, got[i], w)
+				}
+				if math.Abs(got[i].U-tt.wantU) > epsilon || math.Abs(got[i].V-tt.wantV) > epsilon {
+					t.Errorf("LocalIntersect[%v] UV = (%v,%v), want (%v,%v)", i, got[i].U, got[i].V, tt.wantU, tt.wantV)
+				}
+			}
+		})
+	}
+}
+
+func TestAnnulusT_Bounds(t *testing.T) {
+	a := Annulus()
+	a.OuterRadius = 3
+
+	b := a.Bounds()
+	if got, want := b.Min, Point(-3, 0, -3); !got.Equal(want) {
+		t.Errorf("Bounds.Min = %v, want %v", got, want)
+	}
+	if got, want := b.Max, Point(3, 0, 3); !got.Equal(want) {
+		t.Errorf("Bounds.Max = %v, want %v", got, want)
+	}
+}
+
+func TestAnnulusT_SampleSurface(t *testing.T) {
+	a := Annulus()
+
+	for _, v := range []float64{0, 0.3, 0.7, 1} {
+		p, _ := a.SampleSurface(0.4, v)
+		if r := math.Sqrt(p.X()*p.X() + p.Z()*p.Z()); r < a.InnerRadius-epsilon || r > a.OuterRadius+epsilon || p.Y() != 0 {
+			t.Errorf("SampleSurface(0.4,%v) = %v, want a point on the ring", v, p)
+		}
+	}
+}
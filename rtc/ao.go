@@ -0,0 +1,134 @@
+package rtc
+
+import (
+	"log"
+	"math"
+	"math/rand"
+)
+
+// AmbientOcclusionT darkens the ambient term of the shading in crevices
+// and corners by the fraction of the hemisphere above each hit that is
+// blocked by nearby objects.
+type AmbientOcclusionT struct {
+	// Samples is the number of directions tested per shading point.
+	Samples int
+	// MaxDistance is the distance beyond which objects no longer occlude.
+	// Zero (or infinity) lets objects at any distance occlude.
+	MaxDistance float64
+	Jitter      bool
+}
+
+// AmbientOcclusion returns ambient occlusion testing the provided number
+// of samples per shading point for objects within maxDistance.
+func AmbientOcclusion(samples int, maxDistance float64) *AmbientOcclusionT {
+	if samples < 1 {
+		log.Fatalf("programming error - ambient occlusion needs at least one sample, got %v", samples)
+	}
+	return &AmbientOcclusionT{
+		Samples:     samples,
+		MaxDistance: maxDistance,
+		Jitter:      true,
+	}
+}
+
+// radicalInverse returns the base 2 radical inverse (van der Corput
+// sequence) of i, which mirrors its binary digits about the binary point.
+func radicalInverse(i int) float64 {
+	var result float64
+	f := 0.5
+	for ; i > 0; i >>= 1 {
+		if i&1 != 0 {
+			result += f
+		}
+		f /= 2
+	}
+	return result
+}
+
+// orthonormalBasis returns two unit vectors that form an orthonormal basis
+// together with the unit vector n.
+func orthonormalBasis(n Tuple) (Tuple, Tuple) {
+	a := Vector(1, 0, 0)
+	if math.Abs(n.X()) > 0.9 {
+		a = Vector(0, 1, 0)
+	}
+	t := a.Sub(n.MultScalar(a.Dot(n))).Normalize()
+	return t, n.Cross(t)
+}
+
+// directions returns cosine-weighted directions in the hemisphere around
+// the unit normal, distributed with a Hammersley point set that is
+// randomly shifted when jittering.
+func (a *AmbientOcclusionT) directions(normal Tuple) []Tuple {
+	t, b := orthonormalBasis(normal)
+
+	du, dv := 0.0, 0.0
+	if a.Jitter {
+		du, dv = rand.Float64(), rand.Float64()
+	}
+
+	result := make([]Tuple, a.Samples)
+	for i := range result {
+		u := math.Mod((float64(i)+0.5)/float64(a.Samples)+du, 1)
+		v := math.Mod(radicalInverse(i)+dv, 1)
+
+		r := math.Sqrt(u)
+		sinPhi, cosPhi := math.Sincos(2 * math.Pi * v)
+		result[i] = t.MultScalar(r * cosPhi).Add(b.MultScalar(r * sinPhi)).Add(normal.MultScalar(math.Sqrt(1 - u)))
+	}
+	return result
+}
+
+// Occlusion returns the fraction (in [0,1]) of the hemisphere around the
+// unit normal at the point that is not blocked by objects within the
+// maximum distance, weighted by the cosine of the angle to the normal.
+// The point should be slightly above the surface (such as
+// Comps.OverPoint).
+func (w *WorldT) Occlusion(ao *AmbientOcclusionT, point, normal Tuple) float64 {
+	maxDistance := ao.MaxDistance
+	if maxDistance <= 0 {
+		maxDistance = math.Inf(1)
+	}
+
+	var unoccluded int
+	for _, direction := range ao.directions(normal) {
+		if h := surfaceHit(w.IntersectWorld(Ray(point, direction))); h == nil || h.T > maxDistance {
+			unoccluded++
+		}
+	}
+	return float64(unoccluded) / float64(ao.Samples)
+}
+
+// ambientScale returns the factor applied to the ambient term of the
+// shading at the precomputed intersection.
+func (w *WorldT) ambientScale(comps *Comps) float64 {
+	if w.AmbientOcclusion == nil {
+		return 1
+	}
+	return w.Occlusion(w.AmbientOcclusion, comps.OverPoint, comps.NormalVector)
+}
+
+// AmbientOcclusionAt returns the grayscale occlusion seen along the ray:
+// white where the surface is completely open, black where it is completely
+// occluded, and white for rays that miss every object.
+func (w *WorldT) AmbientOcclusionAt(ao *AmbientOcclusionT, ray RayT) Tuple {
+	xs := w.IntersectWorld(ray)
+	hit := surfaceHit(xs)
+	if hit == nil {
+		return Color(1, 1, 1)
+	}
+
+	comps := hit.PrepareComputations(ray, xs)
+	f := w.Occlusion(ao, comps.OverPoint, comps.NormalVector)
+	return Color(f, f, f)
+}
+
+// RenderAmbientOcclusion renders the ambient occlusion of the world with
+// the camera as a grayscale image, without any other shading.
+func (c *CameraT) RenderAmbientOcclusion(world *WorldT, ao *AmbientOcclusionT) *Canvas {
+	canvas := NewCanvas(c.HSize, c.VSize)
+	c.forEachPixel(c.RayForPixel, func(x, y int, ray RayT) {
+		canvas.WritePixel(x, y, world.AmbientOcclusionAt(ao, ray))
+	})
+	return canvas
+}
@@ -0,0 +1,147 @@
+package rtc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestRadicalInverse(t *testing.T) {
+	tests := []struct {
+		i    int
+		want float64
+	}{
+		{i: 0, want: 0},
+		{i: 1, want: 0.5},
+		{i: 2, want: 0.25},
+		{i: 3, want: 0.75},
+		{i: 4, want: 0.125},
+		{i: 6, want: 0.375},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.i), func(t *testing.T) {
+			if got := radicalInverse(tt.i); got != tt.want {
+				t.Errorf("radicalInverse(%v) = %v, want %v", tt.i, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmbientOcclusionT_Directions(t *testing.T) {
+	normals := []Tuple{Vector(0, 1, 0), Vector(1, 0, 0), Vector(0, 0, -1), Vector(1, 1, 1).Normalize()}
+	for _, normal := range normals {
+		t.Run(fmt.Sprintf("%v", normal), func(t *testing.T) {
+			ao := AmbientOcclusion(64, 0)
+			directions := ao.directions(normal)
+			if got, want := len(directions), 64; got != want {
+				t.Fatalf("len(directions) = %v, want %v", got, want)
+			}
+
+			var sum float64
+			for i, d := range directions {
+				if got := d.Magnitude(); math.Abs(got-1) > epsilon {
+					t.Errorf("directions[%v] magnitude = %v, want 1", i, got)
+				}
+				cos := d.Dot(normal)
+				if cos < 0 {
+					t.Errorf("directions[%v] = %v, want in the hemisphere around %v", i, d, normal)
+				}
+				sum += cos
+			}
+			// Cosine-weighted directions have a mean cosine of 2/3.
+			if got := sum / 64; math.Abs(got-2.0/3) > 0.02 {
+				t.Errorf("mean cosine = %v, want about 2/3", got)
+			}
+		})
+	}
+}
+
+func TestWorldT_Occlusion(t *testing.T) {
+	wall := Plane()
+	wall.SetTransform(Translation(0, 0, 2).Mult(RotationX(math.Pi / 2)))
+	box := Cube()
+	box.SetTransform(Scaling(5, 5, 5))
+
+	tests := []struct {
+		name        string
+		objects     []Object
+		maxDistance float64
+		min, max    float64
+	}{
+		{name: "open floor", min: 1, max: 1},
+		{name: "inside a box", objects: []Object{box}, min: 0, max: 0},
+		{name: "inside a box beyond the maximum distance", objects: []Object{box}, maxDistance: 4, min: 1, max: 1},
+		{name: "next to a wall", objects: []Object{wall}, min: 0.45, max: 0.55},
+		{name: "next to a wall beyond the maximum distance", objects: []Object{wall}, maxDistance: 1, min: 1, max: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := World()
+			w.Objects = append([]Object{Plane()}, tt.objects...)
+
+			ao := AmbientOcclusion(256, tt.maxDistance)
+			ao.Jitter = false
+
+			got := w.Occlusion(ao, Point(0, epsilon, 0), Vector(0, 1, 0))
+			if got < tt.min || got > tt.max {
+				t.Errorf("Occlusion = %v, want in [%v,%v]", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestWorldT_ShadeHit_AmbientOcclusion(t *testing.T) {
+	floor := Plane()
+	floor.GetMaterial().Ambient = 1
+	blocker := Sphere()
+	blocker.SetTransform(Translation(0, 1.5, 0))
+
+	w := World()
+	w.Objects = []Object{floor, blocker}
+	w.Lights = []*PointLightT{PointLight(Point(0, 10, 0), Color(1, 1, 1))}
+
+	r := Ray(Point(0, 0.25, -1), Vector(0, -0.25, 1).Normalize())
+	xs := w.IntersectWorld(r)
+	comps := Hit(xs).PrepareComputations(r, xs)
+
+	// In the shadow of the sphere, only the ambient term remains.
+	if got, want := w.ShadeHit(comps, maxReflections), Color(1, 1, 1); !got.Equal(want) {
+		t.Errorf("ShadeHit without ambient occlusion = %v, want %v", got, want)
+	}
+
+	w.AmbientOcclusion = AmbientOcclusion(64, 0)
+	w.AmbientOcclusion.Jitter = false
+	f := w.Occlusion(w.AmbientOcclusion, comps.OverPoint, comps.NormalVector)
+	if f <= 0 || f >= 1 {
+		t.Fatalf("Occlusion = %v, want partially occluded", f)
+	}
+	if got, want := w.ShadeHit(comps, maxReflections), Color(f, f, f); !got.Equal(want) {
+		t.Errorf("ShadeHit with ambient occlusion = %v, want %v", got, want)
+	}
+}
+
+func TestCameraT_RenderAmbientOcclusion(t *testing.T) {
+	s := Sphere()
+	s.SetTransform(Translation(0, 1, 0))
+	w := World()
+	w.Objects = []Object{Plane(), s}
+
+	c := Camera(11, 11, math.Pi/2)
+	c.Transform = ViewTransform(Point(0, 1, -5), Point(0, 1, 0), Vector(0, 1, 0))
+
+	ao := AmbientOcclusion(64, 0)
+	ao.Jitter = false
+	image := c.RenderAmbientOcclusion(w, ao)
+
+	// The top of the image sees the empty sky.
+	if got, want := image.PixelAt(5, 0), Color(1, 1, 1); !got.Equal(want) {
+		t.Errorf("sky pixel = %v, want %v", got, want)
+	}
+	// The bottom of the sphere is partially occluded by the floor.
+	got := image.PixelAt(5, 7)
+	if got.Red() <= 0 || got.Red() >= 1 || got.Red() != got.Green() || got.Red() != got.Blue() {
+		t.Errorf("sphere pixel = %v, want gray", got)
+	}
+}
@@ -0,0 +1,268 @@
+package rtc
+
+import (
+	"fmt"
+	"math"
+)
+
+// Pass selects one of the arbitrary output variables (AOVs) rendered by
+// CameraT.RenderPasses for compositing.
+type Pass int
+
+const (
+	// BeautyPass is the final rendered color, as returned by Render.
+	BeautyPass Pass = iota
+	// DepthPass is the distance from the camera to the visible surface,
+	// or +Inf where the ray misses every object.
+	DepthPass
+	// NormalPass is the world space unit normal (facing the camera) of the
+	// visible surface in X, Y and Z.
+	NormalPass
+	// AlbedoPass is the surface color (including its pattern) of the
+	// visible surface before any lighting.
+	AlbedoPass
+	// ObjectIDPass is the ID of the visible object (see
+	// RenderPassesT.Objects), or 0 where the ray misses every object.
+	ObjectIDPass
+	// MaterialIDPass is the ID of the material of the visible object (see
+	// RenderPassesT.Materials), or 0 if it is unknown.
+	MaterialIDPass
+	// DirectPass is the emitted and directly lit color of the visible
+	// surface.
+	DirectPass
+	// ReflectedPass is the reflected color of the visible surface.
+	ReflectedPass
+	// RefractedPass is the refracted color of the visible surface.
+	RefractedPass
+
+	numPasses
+)
+
+// passNames are the names of the passes, which are used in file names.
+var passNames = [numPasses]string{
+	"beauty",
+	"depth",
+	"normal",
+	"albedo",
+	"object-id",
+	"material-id",
+	"direct",
+	"reflected",
+	"refracted",
+}
+
+// String returns the name of the pass.
+func (p Pass) String() string {
+	if p < 0 || p >= numPasses {
+		return fmt.Sprintf("Pass(%d)", int(p))
+	}
+	return passNames[p]
+}
+
+// RenderPassesT holds the arbitrary output variables (AOVs) of a render.
+// Each pass stores its raw values: IDs and depths are stored in all three
+// color channels. The direct, reflected and refracted passes add up to
+// the beauty pass, except where participating media or fog change it.
+type RenderPassesT struct {
+	Canvases [numPasses]*Canvas
+
+	// Objects holds the objects by ID, where the object with ID i is
+	// Objects[i-1]. The top-level objects of the world and all of their
+	// group and CSG descendants are numbered in order; hits on other
+	// objects (such as the faces of instanced geometry) use the ID of their
+	// nearest numbered ancestor.
+	Objects []Object
+	// Materials holds the materials by ID in the same way, in the order
+	// they are first found on the objects.
+	Materials []*MaterialT
+
+	objectIDs   map[Object]int
+	materialIDs map[*MaterialT]int
+}
+
+// newRenderPasses returns empty passes of the provided size for the
+// objects of the world.
+func newRenderPasses(width, height int, world *WorldT) *RenderPassesT {
+	r := &RenderPassesT{
+		objectIDs:   map[Object]int{},
+		materialIDs: map[*MaterialT]int{},
+	}
+	for i := range r.Canvases {
+		r.Canvases[i] = NewCanvas(width, height)
+	}
+	for _, object := range world.Objects {
+		r.number(object, true)
+	}
+	return r
+}
+
+// number assigns IDs to the object (if numbered) and its material, and
+// then to its descendants.
+func (r *RenderPassesT) number(object Object, numbered bool) {
+	if _, ok := r.objectIDs[object]; numbered && !ok {
+		r.Objects = append(r.Objects, object)
+		r.objectIDs[object] = len(r.Objects)
+	}
+	if m := object.GetMaterial(); m != nil {
+		if _, ok := r.materialIDs[m]; !ok {
+			r.Materials = append(r.Materials, m)
+			r.materialIDs[m] = len(r.Materials)
+		}
+	}
+
+	switch o := object.(type) {
+	case *GroupT:
+		for _, child := range o.Children {
+			r.number(child, numbered)
+		}
+	case *CSGT:
+		r.number(o.Left, numbered)
+		r.number(o.Right, numbered)
+	case *InstanceT:
+		// Only the materials of shared geometry are numbered since its
+		// objects are hit through the instance.
+		r.number(o.Object, false)
+	}
+}
+
+// objectID returns the ID of the object or of its nearest numbered
+// ancestor.
+func (r *RenderPassesT) objectID(object Object) int {
+	for o := object; o != nil; o = o.GetParent() {
+		if id, ok := r.objectIDs[o]; ok {
+			return id
+		}
+	}
+	return 0
+}
+
+// Pass returns the canvas holding the raw values of the pass.
+func (r *RenderPassesT) Pass(p Pass) *Canvas {
+	return r.Canvases[p]
+}
+
+// Image returns the pass converted to a viewable image: depths are
+// mapped from white (nearest) to black (farthest or missed), normals are
+// mapped from [-1,1] to [0,1], and IDs are shown in distinct colors.
+// Other passes are returned as is.
+func (r *RenderPassesT) Image(p Pass) *Canvas {
+	src := r.Canvases[p]
+	switch p {
+	case DepthPass:
+		near, far := math.Inf(1), 0.0
+		for _, v := range src.pixels {
+			if d := v.Red(); !math.IsInf(d, 1) {
+				near, far = math.Min(near, d), math.Max(far, d)
+			}
+		}
+		return mapCanvas(src, func(v Tuple) Tuple {
+			d := v.Red()
+			if math.IsInf(d, 1) {
+				return Color(0, 0, 0)
+			}
+			f := 1.0
+			if far > near {
+				f = 1 - (d-near)/(far-near)
+			}
+			return Color(f, f, f)
+		})
+	case NormalPass:
+		return mapCanvas(src, func(v Tuple) Tuple {
+			if v.Equal(Color(0, 0, 0)) {
+				return v
+			}
+			return Color((v.X()+1)/2, (v.Y()+1)/2, (v.Z()+1)/2)
+		})
+	case ObjectIDPass, MaterialIDPass:
+		return mapCanvas(src, func(v Tuple) Tuple {
+			return idColor(int(v.Red()))
+		})
+	}
+	return src
+}
+
+// mapCanvas returns a new canvas with f applied to every pixel.
+func mapCanvas(src *Canvas, f func(Tuple) Tuple) *Canvas {
+	c := NewCanvas(src.width, src.height)
+	for i, v := range src.pixels {
+		c.pixels[i] = f(v)
+	}
+	return c
+}
+
+// idColor returns a distinct, fully saturated color for the ID, or black
+// for 0. Successive IDs are spread around the hue circle by the golden
+// ratio so that neighboring IDs are easy to tell apart.
+func idColor(id int) Tuple {
+	if id <= 0 {
+		return Color(0, 0, 0)
+	}
+	hue := math.Mod(float64(id)*0.618033988749895, 1) * 6
+	x := 1 - math.Abs(math.Mod(hue, 2)-1)
+	switch int(hue) {
+	case 0:
+		return Color(1, x, 0)
+	case 1:
+		return Color(x, 1, 0)
+	case 2:
+		return Color(0, 1, x)
+	case 3:
+		return Color(0, x, 1)
+	case 4:
+		return Color(x, 0, 1)
+	default:
+		return Color(1, 0, x)
+	}
+}
+
+// WritePNGFiles writes the image (see Image) of every pass to a separate
+// PNG file named "<prefix>-<pass>.png", such as "scene-depth.png".
+func (r *RenderPassesT) WritePNGFiles(prefix string) error {
+	for p := Pass(0); p < numPasses; p++ {
+		if err := r.Image(p).WritePNGFile(fmt.Sprintf("%v-%v.png", prefix, p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePixel renders all passes for the ray at the pixel.
+func (r *RenderPassesT) writePixel(world *WorldT, x, y int, ray RayT) {
+	xs := world.IntersectWorld(ray)
+	hit := surfaceHit(xs)
+	if hit == nil {
+		color := world.applyAtmosphere(ray, xs, math.Inf(1), world.environmentColor(ray.Direction))
+		r.Canvases[BeautyPass].WritePixel(x, y, color)
+		r.Canvases[DepthPass].WritePixel(x, y, Color(math.Inf(1), math.Inf(1), math.Inf(1)))
+		return
+	}
+
+	comps := hit.PrepareComputations(ray, xs)
+	direct, reflected, refracted := world.shadeHitParts(comps, maxReflections)
+	color := world.applyAtmosphere(ray, xs, hit.T, direct.Add(reflected).Add(refracted))
+
+	material := comps.Object.GetMaterial()
+	depth := hit.T * ray.Direction.Magnitude()
+	objectID := float64(r.objectID(comps.Object))
+	materialID := float64(r.materialIDs[material])
+
+	r.Canvases[BeautyPass].WritePixel(x, y, color)
+	r.Canvases[DepthPass].WritePixel(x, y, Color(depth, depth, depth))
+	r.Canvases[NormalPass].WritePixel(x, y, Color(comps.NormalVector.X(), comps.NormalVector.Y(), comps.NormalVector.Z()))
+	r.Canvases[AlbedoPass].WritePixel(x, y, surfaceColor(material, comps.Object, comps.Point))
+	r.Canvases[ObjectIDPass].WritePixel(x, y, Color(objectID, objectID, objectID))
+	r.Canvases[MaterialIDPass].WritePixel(x, y, Color(materialID, materialID, materialID))
+	r.Canvases[DirectPass].WritePixel(x, y, direct)
+	r.Canvases[ReflectedPass].WritePixel(x, y, reflected)
+	r.Canvases[RefractedPass].WritePixel(x, y, refracted)
+}
+
+// RenderPasses renders the world with the camera like Render, along with
+// the arbitrary output variables (AOVs) of every pixel for compositing.
+func (c *CameraT) RenderPasses(world *WorldT) *RenderPassesT {
+	r := newRenderPasses(c.HSize, c.VSize, world)
+	c.forEachPixel(c.RayForPixel, func(x, y int, ray RayT) {
+		r.writePixel(world, x, y, ray)
+	})
+	return r
+}
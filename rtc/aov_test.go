@@ -0,0 +1,181 @@
+package rtc
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPass_String(t *testing.T) {
+	tests := []struct {
+		pass Pass
+		want string
+	}{
+		{pass: BeautyPass, want: "beauty"},
+		{pass: DepthPass, want: "depth"},
+		{pass: ObjectIDPass, want: "object-id"},
+		{pass: RefractedPass, want: "refracted"},
+		{pass: Pass(42), want: "Pass(42)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.pass.String(); got != tt.want {
+				t.Errorf("String = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCameraT_RenderPasses(t *testing.T) {
+	w := DefaultWorld()
+	c := Camera(11, 11, math.Pi/2)
+	c.Transform = ViewTransform(Point(0, 0, -5), Point(0, 0, 0), Vector(0, 1, 0))
+
+	passes := c.RenderPasses(w)
+	beauty := c.Render(w)
+
+	for y := 0; y < 11; y++ {
+		for x := 0; x < 11; x++ {
+			if got, want := passes.Pass(BeautyPass).PixelAt(x, y), beauty.PixelAt(x, y); !got.Equal(want) {
+				t.Errorf("beauty pixel (%v,%v) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	if got, want := len(passes.Objects), 2; got != want {
+		t.Fatalf("len(Objects) = %v, want %v", got, want)
+	}
+	if got, want := len(passes.Materials), 2; got != want {
+		t.Fatalf("len(Materials) = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		pass Pass
+		want Tuple
+	}{
+		{name: "center depth", x: 5, y: 5, pass: DepthPass, want: Color(4, 4, 4)},
+		{name: "center normal", x: 5, y: 5, pass: NormalPass, want: Color(0, 0, -1)},
+		{name: "center albedo", x: 5, y: 5, pass: AlbedoPass, want: Color(0.8, 1, 0.6)},
+		{name: "center object ID", x: 5, y: 5, pass: ObjectIDPass, want: Color(1, 1, 1)},
+		{name: "center material ID", x: 5, y: 5, pass: MaterialIDPass, want: Color(1, 1, 1)},
+		{name: "center direct", x: 5, y: 5, pass: DirectPass, want: Color(0.38066, 0.47583, 0.2855)},
+		{name: "center reflected", x: 5, y: 5, pass: ReflectedPass, want: Color(0, 0, 0)},
+		{name: "center refracted", x: 5, y: 5, pass: RefractedPass, want: Color(0, 0, 0)},
+		{name: "corner normal", x: 0, y: 0, pass: NormalPass, want: Color(0, 0, 0)},
+		{name: "corner object ID", x: 0, y: 0, pass: ObjectIDPass, want: Color(0, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := passes.Pass(tt.pass).PixelAt(tt.x, tt.y); !got.Equal(tt.want) {
+				t.Errorf("%v pixel (%v,%v) = %v, want %v", tt.pass, tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+
+	if got := passes.Pass(DepthPass).PixelAt(0, 0).Red(); !math.IsInf(got, 1) {
+		t.Errorf("corner depth = %v, want +Inf", got)
+	}
+}
+
+func TestCameraT_RenderPasses_Parts(t *testing.T) {
+	floor := Plane()
+	floor.SetTransform(Translation(0, -1, 0))
+	floor.GetMaterial().Reflective = 0.5
+	ball := Sphere()
+	ball.GetMaterial().Color = Color(1, 0.2, 0.2)
+	group := Group(ball)
+
+	w := World()
+	w.Objects = []Object{floor, group}
+	w.Lights = []*PointLightT{PointLight(Point(-10, 10, -10), Color(1, 1, 1))}
+
+	c := Camera(21, 21, math.Pi/3)
+	c.Transform = ViewTransform(Point(0, 1, -5), Point(0, 0, 0), Vector(0, 1, 0))
+	passes := c.RenderPasses(w)
+
+	if got, want := passes.Objects, []Object{floor, group, ball}; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("Objects = %v, want %v", got, want)
+	}
+
+	var reflected bool
+	for y := 0; y < 21; y++ {
+		for x := 0; x < 21; x++ {
+			sum := passes.Pass(DirectPass).PixelAt(x, y).Add(passes.Pass(ReflectedPass).PixelAt(x, y)).Add(passes.Pass(RefractedPass).PixelAt(x, y))
+			if got := passes.Pass(BeautyPass).PixelAt(x, y); !got.Equal(sum) {
+				t.Errorf("beauty pixel (%v,%v) = %v, want sum of parts %v", x, y, got, sum)
+			}
+			if passes.Pass(ReflectedPass).PixelAt(x, y).Red() > 0 {
+				reflected = true
+			}
+		}
+	}
+	if !reflected {
+		t.Error("reflected pass is black, want reflections of the floor")
+	}
+
+	// The ball is a child of the group and has its own ID.
+	if got, want := passes.Pass(ObjectIDPass).PixelAt(10, 10), Color(3, 3, 3); !got.Equal(want) {
+		t.Errorf("ball object ID = %v, want %v", got, want)
+	}
+	if got, want := passes.Pass(AlbedoPass).PixelAt(10, 10), Color(1, 0.2, 0.2); !got.Equal(want) {
+		t.Errorf("ball albedo = %v, want %v", got, want)
+	}
+}
+
+func TestRenderPassesT_Image(t *testing.T) {
+	w := World()
+	w.Objects = []Object{Sphere()}
+	c := Camera(3, 1, math.Pi/2)
+	c.Transform = ViewTransform(Point(0, 0, -5), Point(0, 0, 0), Vector(0, 1, 0))
+	passes := c.RenderPasses(w)
+
+	tests := []struct {
+		name string
+		pass Pass
+		x    int
+		want Tuple
+	}{
+		{name: "nearest depth is white", pass: DepthPass, x: 1, want: Color(1, 1, 1)},
+		{name: "missed depth is black", pass: DepthPass, x: 0, want: Color(0, 0, 0)},
+		{name: "normal", pass: NormalPass, x: 1, want: Color(0.5, 0.5, 0)},
+		{name: "missed normal", pass: NormalPass, x: 0, want: Color(0, 0, 0)},
+		{name: "object ID", pass: ObjectIDPass, x: 1, want: idColor(1)},
+		{name: "missed object ID", pass: ObjectIDPass, x: 0, want: Color(0, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := passes.Image(tt.pass).PixelAt(tt.x, 0); !got.Equal(tt.want) {
+				t.Errorf("Image(%v) pixel %v = %v, want %v", tt.pass, tt.x, got, tt.want)
+			}
+		})
+	}
+
+	for id := 1; id < 8; id++ {
+		if idColor(id).Equal(idColor(id + 1)) {
+			t.Errorf("idColor(%v) = idColor(%v) = %v, want distinct colors", id, id+1, idColor(id))
+		}
+	}
+}
+
+func TestRenderPassesT_WritePNGFiles(t *testing.T) {
+	w := DefaultWorld()
+	c := Camera(4, 4, math.Pi/2)
+	c.Transform = ViewTransform(Point(0, 0, -5), Point(0, 0, 0), Vector(0, 1, 0))
+	passes := c.RenderPasses(w)
+
+	prefix := filepath.Join(t.TempDir(), "scene")
+	if err := passes.WritePNGFiles(prefix); err != nil {
+		t.Fatal(err)
+	}
+	for p := Pass(0); p < numPasses; p++ {
+		if _, err := os.Stat(prefix + "-" + p.String() + ".png"); err != nil {
+			t.Errorf("missing %v pass file: %v", p, err)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package rtc
+
+const (
+	// BezierTolerance is the default maximum distance (in object space)
+	// between a Bezier patch and its tessellation.
+	BezierTolerance = 0.005
+
+	// bezierMaxDepth limits the number of times a patch is subdivided,
+	// resulting in at most 2*4^bezierMaxDepth triangles.
+	bezierMaxDepth = 6
+)
+
+// BezierPatch creates a bicubic Bezier patch from its 16 control points
+// in row-major order, where points[4*i+j] is the control point in row i
+// (along v) and column j (along u).
+// The patch is tessellated into triangles using BezierTolerance; the
+// U and V values of each intersection are the patch parameters of the
+// hit, which are used to compute the exact surface normal.
+// It implements the Object interface.
+func BezierPatch(points [16]Tuple) *BezierPatchT {
+	b := &BezierPatchT{
+		Shape:  Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Points: points,
+	}
+	b.Tessellate(BezierTolerance)
+	return b
+}
+
+// BezierPatchT represents a bicubic Bezier patch.
+type BezierPatchT struct {
+	Shape
+	Points [16]Tuple
+
+	root *bezierNode
+}
+
+var _ Object = &BezierPatchT{}
+
+// bezierNode is a node of the quadtree produced by subdividing the
+// parameter domain of a patch. Leaf nodes hold two triangles.
+type bezierNode struct {
+	bounds    *BoundsT
+	children  []*bezierNode
+	triangles []bezierTriangle
+}
+
+// bezierTriangle is a triangle of the tessellation along with the patch
+// parameters at each of its vertices.
+type bezierTriangle struct {
+	p1, p2, p3 Tuple
+	uv1        [2]float64
+	uv2        [2]float64
+	uv3        [2]float64
+}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (b *BezierPatchT) SetTransform(m M4) Object {
+	b.Transform = m
+	return b
+}
+
+// SetMaterial sets the object's material.
+func (b *BezierPatchT) SetMaterial(material MaterialT) Object {
+	b.setMaterial(material)
+	return b
+}
+
+// SetParent sets the object's parent object.
+func (b *BezierPatchT) SetParent(parent Object) Object {
+	b.Parent = parent
+	return b
+}
+
+// Tessellate rebuilds the triangles of the patch so that they lie within
+// the provided distance of the true surface. It must be called after
+// modifying the control points.
+//
+// The patch is uniformly subdivided to the shallowest depth at which every
+// sub-patch is flat enough, which keeps the tessellation free of cracks
+// within the patch while spending triangles only on curved patches.
+func (b *BezierPatchT) Tessellate(tolerance float64) {
+	depth := 0
+	for depth < bezierMaxDepth && !b.isFlat(depth, tolerance) {
+		depth++
+	}
+	b.root = b.subdivide(0, 1, 0, 1, depth)
+}
+
+// PointAt returns the point on the patch at the provided parameters.
+func (b *BezierPatchT) PointAt(u, v float64) Tuple {
+	bu, bv := bernstein(u), bernstein(v)
+	p := Point(0, 0, 0)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			w := bv[i] * bu[j]
+			p[0] += w * b.Points[4*i+j].X()
+			p[1] += w * b.Points[4*i+j].Y()
+			p[2] += w * b.Points[4*i+j].Z()
+		}
+	}
+	return p
+}
+
+// NormalAt returns the (unnormalized) surface normal of the patch at the
+// provided parameters.
+func (b *BezierPatchT) NormalAt(u, v float64) Tuple {
+	n := b.normalAt(u, v)
+	// Degenerate patches (such as those at the top and bottom of the
+	// teapot) collapse an edge to a point where the normal is undefined,
+	// so step slightly toward the center of the patch.
+	for i := 0; i < 8 && n.Magnitude() < eqnEpsilon; i++ {
+		u += (0.5 - u) * 1e-3
+		v += (0.5 - v) * 1e-3
+		n = b.normalAt(u, v)
+	}
+	return n
+}
+
+func (b *BezierPatchT) normalAt(u, v float64) Tuple {
+	bu, bv := bernstein(u), bernstein(v)
+	du, dv := bernsteinDerivative(u), bernsteinDerivative(v)
+	tu, tv := Vector(0, 0, 0), Vector(0, 0, 0)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			p := b.Points[4*i+j]
+			for k := 0; k < 3; k++ {
+				tu[k] += bv[i] * du[j] * p[k]
+				tv[k] += dv[i] * bu[j] * p[k]
+			}
+		}
+	}
+	return tu.Cross(tv)
+}
+
+// bernstein returns the cubic Bernstein basis polynomials at t.
+func bernstein(t float64) [4]float64 {
+	s := 1 - t
+	return [4]float64{s * s * s, 3 * t * s * s, 3 * t * t * s, t * t * t}
+}
+
+// bernsteinDerivative returns the derivatives of the cubic Bernstein
+// basis polynomials at t.
+func bernsteinDerivative(t float64) [4]float64 {
+	s := 1 - t
+	return [4]float64{-3 * s * s, 3*s*s - 6*t*s, 6*t*s - 3*t*t, 3 * t * t}
+}
+
+// isFlat reports whether every sub-patch at the provided subdivision depth
+// deviates from the bilinear patch through its corners by less than the
+// tolerance.
+func (b *BezierPatchT) isFlat(depth int, tolerance float64) bool {
+	n := 1 << uint(depth)
+	step := 1 / float64(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			u0, v0 := float64(j)*step, float64(i)*step
+			u1, v1 := u0+step, v0+step
+			p00, p10 := b.PointAt(u0, v0), b.PointAt(u1, v0)
+			p01, p11 := b.PointAt(u0, v1), b.PointAt(u1, v1)
+			um, vm := (u0+u1)/2, (v0+v1)/2
+
+			checks := []struct {
+				u, v float64
+				want Tuple
+			}{
+				{u: um, v: v0, want: p00.Add(p10).DivScalar(2)},
+				{u: um, v: v1, want: p01.Add(p11).DivScalar(2)},
+				{u: u0, v: vm, want: p00.Add(p01).DivScalar(2)},
+				{u: u1, v: vm, want: p10.Add(p11).DivScalar(2)},
+				{u: um, v: vm, want: p00.Add(p10).Add(p01).Add(p11).DivScalar(4)},
+			}
+			for _, c := range checks {
+				if b.PointAt(c.u, c.v).Sub(c.want).Magnitude() >= tolerance {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// subdivide builds the quadtree for the parameter range [u0,u1]x[v0,v1].
+func (b *BezierPatchT) subdivide(u0, u1, v0, v1 float64, depth int) *bezierNode {
+	node := &bezierNode{bounds: Bounds()}
+	if depth > 0 {
+		um, vm := (u0+u1)/2, (v0+v1)/2
+		node.children = []*bezierNode{
+			b.subdivide(u0, um, v0, vm, depth-1),
+			b.subdivide(um, u1, v0, vm, depth-1),
+			b.subdivide(u0, um, vm, v1, depth-1),
+			b.subdivide(um, u1, vm, v1, depth-1),
+		}
+		for _, child := range node.children {
+			node.bounds.UpdateBounds(child.bounds.Min)
+			node.bounds.UpdateBounds(child.bounds.Max)
+		}
+		return node
+	}
+
+	uv00, uv10 := [2]float64{u0, v0}, [2]float64{u1, v0}
+	uv01, uv11 := [2]float64{u0, v1}, [2]float64{u1, v1}
+	for _, tri := range [][3][2]float64{{uv00, uv10, uv11}, {uv00, uv11, uv01}} {
+		p1 := b.PointAt(tri[0][0], tri[0][1])
+		p2 := b.PointAt(tri[1][0], tri[1][1])
+		p3 := b.PointAt(tri[2][0], tri[2][1])
+		node.bounds.UpdateBounds(p1)
+		node.bounds.UpdateBounds(p2)
+		node.bounds.UpdateBounds(p3)
+		node.triangles = append(node.triangles, bezierTriangle{
+			p1:  p1,
+			p2:  p2,
+			p3:  p3,
+			uv1: tri[0],
+			uv2: tri[1],
+			uv3: tri[2],
+		})
+	}
+	return node
+}
+
+// SmoothTriangles returns the current tessellation of the patch as smooth
+// triangles with exact vertex normals, for use in meshes and groups.
+func (b *BezierPatchT) SmoothTriangles() []*SmoothTriangleT {
+	var result []*SmoothTriangleT
+	var walk func(n *bezierNode)
+	walk = func(n *bezierNode) {
+		for _, child := range n.children {
+			walk(child)
+		}
+		for _, t := range n.triangles {
+			result = append(result, SmoothTriangle(
+				t.p1, t.p2, t.p3,
+				b.NormalAt(t.uv1[0], t.uv1[1]).Normalize(),
+				b.NormalAt(t.uv2[0], t.uv2[1]).Normalize(),
+				b.NormalAt(t.uv3[0], t.uv3[1]).Normalize(),
+			))
+		}
+	}
+	walk(b.root)
+	return result
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+// A Bezier patch always lies within the convex hull of its control points.
+func (b *BezierPatchT) Bounds() *BoundsT {
+	bounds := Bounds()
+	for _, p := range b.Points {
+		bounds.UpdateBounds(p)
+	}
+	return bounds
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+func (b *BezierPatchT) LocalIntersect(ray RayT) []IntersectionT {
+	xs := Intersections(b.root.intersect(ray, b, nil)...)
+
+	// A ray crossing a shared edge hits both adjacent triangles.
+	result := xs[:0]
+	for i, x := range xs {
+		if i > 0 && x.T-xs[i-1].T < epsilon {
+			continue
+		}
+		result = append(result, x)
+	}
+	return result
+}
+
+func (n *bezierNode) intersect(ray RayT, b *BezierPatchT, xs []IntersectionT) []IntersectionT {
+	if len(n.bounds.LocalIntersect(ray, b)) == 0 {
+		return xs
+	}
+	for _, child := range n.children {
+		xs = child.intersect(ray, b, xs)
+	}
+	for _, tri := range n.triangles {
+		xs = tri.intersect(ray, b, xs)
+	}
+	return xs
+}
+
+func (t *bezierTriangle) intersect(ray RayT, b *BezierPatchT, xs []IntersectionT) []IntersectionT {
+	tv, u, v, ok := intersectTriangle(ray, t.p1, t.p2, t.p3)
+	if !ok {
+		return xs
+	}
+
+	// Map the barycentric coordinates back to patch parameters.
+	w := 1 - u - v
+	pu := w*t.uv1[0] + u*t.uv2[0] + v*t.uv3[0]
+	pv := w*t.uv1[1] + u*t.uv2[1] + v*t.uv3[1]
+	return append(xs, IntersectionWithUV(tv, b, pu, pv))
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+// The normal is evaluated exactly at the patch parameters of the hit.
+func (b *BezierPatchT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	return b.NormalAt(hit.U, hit.V).Normalize()
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (b *BezierPatchT) Includes(other Object) bool {
+	return b == other
+}
@@ -0,0 +1,190 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+// flatPatch returns a patch covering the square from (0,0,0) to (3,0,3)
+// where PointAt(u,v) = (3u, 0, 3v).
+func flatPatch() *BezierPatchT {
+	var points [16]Tuple
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			points[4*i+j] = Point(float64(j), 0, float64(i))
+		}
+	}
+	return BezierPatch(points)
+}
+
+// bumpPatch returns the flat patch with its 4 inner control points raised
+// to y=1, peaking at y=0.5625 in the middle of the patch.
+func bumpPatch() *BezierPatchT {
+	var points [16]Tuple
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			y := 0.0
+			if i > 0 && i < 3 && j > 0 && j < 3 {
+				y = 1
+			}
+			points[4*i+j] = Point(float64(j), y, float64(i))
+		}
+	}
+	return BezierPatch(points)
+}
+
+func TestBezierPatchT_PointAt(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BezierPatchT
+		u, v float64
+		want Tuple
+	}{
+		{name: "flat corner", b: flatPatch(), u: 0, v: 0, want: Point(0, 0, 0)},
+		{name: "flat opposite corner", b: flatPatch(), u: 1, v: 1, want: Point(3, 0, 3)},
+		{name: "flat interior", b: flatPatch(), u: 0.5, v: 0.25, want: Point(1.5, 0, 0.75)},
+		{name: "bump center", b: bumpPatch(), u: 0.5, v: 0.5, want: Point(1.5, 0.5625, 1.5)},
+		{name: "bump edge", b: bumpPatch(), u: 0.5, v: 0, want: Point(1.5, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.PointAt(tt.u, tt.v); !got.Equal(tt.want) {
+				t.Errorf("PointAt(%v,%v) = %v, want %v", tt.u, tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBezierPatchT_LocalIntersect(t *testing.T) {
+	flat := flatPatch()
+	bump := bumpPatch()
+
+	tests := []struct {
+		name   string
+		b      *BezierPatchT
+		ray    RayT
+		wantT  []float64
+		wantUV [][2]float64
+		tol    float64
+	}{
+		{
+			name:   "A ray striking a flat patch",
+			b:      flat,
+			ray:    Ray(Point(1.5, 1, 0.75), Vector(0, -1, 0)),
+			wantT:  []float64{1},
+			wantUV: [][2]float64{{0.5, 0.25}},
+			tol:    epsilon,
+		},
+		{
+			name:   "A ray striking the shared edge of two triangles",
+			b:      flat,
+			ray:    Ray(Point(1.5, 1, 1.5), Vector(0, -1, 0)),
+			wantT:  []float64{1},
+			wantUV: [][2]float64{{0.5, 0.5}},
+			tol:    epsilon,
+		},
+		{
+			name: "A ray missing the patch",
+			b:    flat,
+			ray:  Ray(Point(4, 1, 1), Vector(0, -1, 0)),
+		},
+		{
+			name:   "A ray striking the top of a bump",
+			b:      bump,
+			ray:    Ray(Point(1.5, 5, 1.5), Vector(0, -1, 0)),
+			wantT:  []float64{5 - 0.5625},
+			wantUV: [][2]float64{{0.5, 0.5}},
+			tol:    BezierTolerance,
+		},
+		{
+			name:   "A ray passing through both sides of a bump",
+			b:      bump,
+			ray:    Ray(Point(-1, 0.25, 1.5), Vector(1, 0, 0)),
+			wantT:  []float64{1 + 3*0.127322, 1 + 3*0.872678},
+			wantUV: [][2]float64{{0.127322, 0.5}, {0.872678, 0.5}},
+			tol:    BezierTolerance * 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.b.LocalIntersect(tt.ray)
+			if len(got) != len(tt.wantT) {
+				t.Fatalf("LocalIntersect = %v, want %v intersections", got, len(tt.wantT))
+			}
+			for i := range got {
+				if got[i].Object != tt.b {
+					t.Errorf("LocalIntersect[%v].Object = %v, want %v", i, got[i].Object, tt.b)
+				}
+				if math.Abs(got[i].T-tt.wantT[i]) > tt.tol {
+					t.Errorf("LocalIntersect[%v].T = %v, want %v", i, got[i].T, tt.wantT[i])
+				}
+				if math.Abs(got[i].U-tt.wantUV[i][0]) > tt.tol || math.Abs(got[i].V-tt.wantUV[i][1]) > tt.tol {
+					t.Errorf("LocalIntersect[%v] UV = (%v,%v), want %v", i, got[i].U, got[i].V, tt.wantUV[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBezierPatchT_LocalNormalAt(t *testing.T) {
+	// A patch whose first row of control points collapses to a single point.
+	var cone [16]Tuple
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			cone[4*i+j] = Point(float64(j)*float64(i)/3, -float64(i), float64(i))
+		}
+	}
+
+	tests := []struct {
+		name string
+		b    *BezierPatchT
+		u, v float64
+		want Tuple
+	}{
+		{name: "flat", b: flatPatch(), u: 0.3, v: 0.6, want: Vector(0, -1, 0)},
+		{name: "bump center", b: bumpPatch(), u: 0.5, v: 0.5, want: Vector(0, -1, 0)},
+		{name: "degenerate edge", b: BezierPatch(cone), u: 0.5, v: 0, want: Vector(0, -1, -1).Normalize()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit := IntersectionWithUV(0, tt.b, tt.u, tt.v)
+			if got := tt.b.LocalNormalAt(tt.b.PointAt(tt.u, tt.v), &hit); !got.Equal(tt.want) {
+				t.Errorf("LocalNormalAt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBezierPatchT_Tessellate(t *testing.T) {
+	flat := flatPatch()
+	if got, want := len(flat.SmoothTriangles()), 2; got != want {
+		t.Errorf("flat len(SmoothTriangles) = %v, want %v", got, want)
+	}
+
+	bump := bumpPatch()
+	coarse := len(bump.SmoothTriangles())
+	bump.Tessellate(BezierTolerance / 100)
+	fine := len(bump.SmoothTriangles())
+	if fine <= coarse {
+		t.Errorf("finer tessellation has %v triangles, want more than %v", fine, coarse)
+	}
+
+	tri := bump.SmoothTriangles()[0]
+	if want := bump.NormalAt(0, 0).Normalize(); !tri.N1.Equal(want) {
+		t.Errorf("SmoothTriangles[0].N1 = %v, want %v", tri.N1, want)
+	}
+}
+
+func TestBezierPatchT_Bounds(t *testing.T) {
+	b := bumpPatch()
+	got := b.Bounds()
+	if want := Point(0, 0, 0); !got.Min.Equal(want) {
+		t.Errorf("Bounds.Min = %v, want %v", got.Min, want)
+	}
+	if want := Point(3, 1, 3); !got.Max.Equal(want) {
+		t.Errorf("Bounds.Max = %v, want %v", got.Max, want)
+	}
+}
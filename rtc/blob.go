@@ -0,0 +1,195 @@
+package rtc
+
+import (
+	"math"
+	"sort"
+)
+
+// BlobComponent is a single spherical component of a blob. Its field
+// strength falls off smoothly from Strength at Center to zero at Radius.
+// A negative strength subtracts from the blob.
+type BlobComponent struct {
+	Center   Tuple
+	Radius   float64
+	Strength float64
+}
+
+// Blob creates a blobby implicit surface (metaballs) from the provided
+// components. The surface is where the sum of the component fields equals
+// the threshold, with the inside of the blob where the sum exceeds it.
+// Each component contributes Strength*(1-r²/Radius²)² at distance r from
+// its center.
+// It implements the Object interface.
+func Blob(threshold float64, components ...BlobComponent) *BlobT {
+	return &BlobT{
+		Shape:      Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Threshold:  threshold,
+		Components: components,
+	}
+}
+
+// BlobT represents a blob (metaballs).
+type BlobT struct {
+	Shape
+	Threshold  float64
+	Components []BlobComponent
+}
+
+var _ Object = &BlobT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (b *BlobT) SetTransform(m M4) Object {
+	b.Transform = m
+	return b
+}
+
+// SetMaterial sets the object's material.
+func (b *BlobT) SetMaterial(material MaterialT) Object {
+	b.setMaterial(material)
+	return b
+}
+
+// SetParent sets the object's parent object.
+func (b *BlobT) SetParent(parent Object) Object {
+	b.Parent = parent
+	return b
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+// Only components with a positive strength can extend the surface.
+func (b *BlobT) Bounds() *BoundsT {
+	bounds := Bounds()
+	for _, c := range b.Components {
+		if c.Strength <= 0 {
+			continue
+		}
+		r := Vector(c.Radius, c.Radius, c.Radius)
+		bounds.UpdateBounds(c.Center.Sub(r))
+		bounds.UpdateBounds(c.Center.Add(r))
+	}
+	return bounds
+}
+
+// Field returns the sum of the component fields at the object space point.
+func (b *BlobT) Field(p Tuple) float64 {
+	var sum float64
+	for _, c := range b.Components {
+		v := p.Sub(c.Center)
+		g := 1 - v.Dot(v)/(c.Radius*c.Radius)
+		if g > 0 {
+			sum += c.Strength * g * g
+		}
+	}
+	return sum
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+// The ray is split into segments at the points where it enters and leaves
+// the sphere of influence of each component. Within a segment the set of
+// contributing components is fixed, so the field along the ray is a single
+// quartic polynomial whose roots are found exactly.
+func (b *BlobT) LocalIntersect(ray RayT) []IntersectionT {
+	// Solve along a unit direction to keep the polynomials well-conditioned.
+	length := ray.Direction.Magnitude()
+	direction := ray.Direction.DivScalar(length)
+
+	type span struct {
+		t0, t1 float64
+		// Quadratic coefficients of g(t) = 1 - r²/R² along the ray.
+		g2, g1, g0 float64
+		strength   float64
+		radius     float64
+	}
+
+	var spans []span
+	var breaks []float64
+	for _, c := range b.Components {
+		oc := ray.Origin.Sub(c.Center)
+		r2 := c.Radius * c.Radius
+		g2 := -1 / r2
+		g1 := -2 * oc.Dot(direction) / r2
+		g0 := 1 - oc.Dot(oc)/r2
+
+		// Solve r² - |oc + t*direction|² = 0, which has the same roots as
+		// g(t) without vanishing coefficients for large radii.
+		roots := solveQuadratic(-1, -2*oc.Dot(direction), r2-oc.Dot(oc))
+		if len(roots) < 2 {
+			continue
+		}
+		t0, t1 := math.Min(roots[0], roots[1]), math.Max(roots[0], roots[1])
+		spans = append(spans, span{t0: t0, t1: t1, g2: g2, g1: g1, g0: g0, strength: c.Strength, radius: c.Radius})
+		breaks = append(breaks, t0, t1)
+	}
+	if len(breaks) == 0 {
+		return nil
+	}
+	sort.Float64s(breaks)
+
+	var xs []IntersectionT
+	lastT := math.Inf(-1)
+	for i := 0; i+1 < len(breaks); i++ {
+		lo, hi := breaks[i], breaks[i+1]
+		if hi-lo < eqnEpsilon {
+			continue
+		}
+
+		// Sum the squared quadratics of all components active in [lo,hi].
+		mid := (lo + hi) / 2
+		var c4, c3, c2, c1, c0, scale float64
+		var positive bool
+		for _, s := range spans {
+			if mid < s.t0 || mid > s.t1 {
+				continue
+			}
+			positive = positive || s.strength > 0
+			scale = math.Max(scale, s.radius)
+			c4 += s.strength * s.g2 * s.g2
+			c3 += s.strength * 2 * s.g2 * s.g1
+			c2 += s.strength * (s.g1*s.g1 + 2*s.g2*s.g0)
+			c1 += s.strength * 2 * s.g1 * s.g0
+			c0 += s.strength * s.g0 * s.g0
+		}
+		if !positive {
+			continue
+		}
+
+		// The coefficient of t^n scales with 1/Radius^n, so solve for
+		// t/scale instead to keep the leading coefficients from vanishing
+		// for large components.
+		s2 := scale * scale
+		for _, u := range solveQuartic(c4*s2*s2, c3*s2*scale, c2*s2, c1*scale, c0-b.Threshold) {
+			t := u * scale
+			if t < lo-epsilon || t > hi+epsilon || t-lastT < epsilon {
+				continue
+			}
+			xs = append(xs, Intersection(t/length, b))
+			lastT = t
+		}
+	}
+
+	return xs
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+// It is the negated analytic gradient of the field.
+func (b *BlobT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	normal := Vector(0, 0, 0)
+	for _, c := range b.Components {
+		v := objectPoint.Sub(c.Center)
+		r2 := c.Radius * c.Radius
+		g := 1 - v.Dot(v)/r2
+		if g > 0 {
+			normal = normal.Add(v.MultScalar(4 * c.Strength * g / r2))
+		}
+	}
+	return normal
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (b *BlobT) Includes(other Object) bool {
+	return b == other
+}
@@ -0,0 +1,173 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlobT_LocalIntersect(t *testing.T) {
+	// The surface of a single component with unit radius and strength
+	// at a threshold of 0.5 is a sphere with radius r where (1-r²)² = 0.5.
+	r := math.Sqrt(1 - math.Sqrt(0.5))
+	// Carving out a component with radius 0.5 leaves an inner surface
+	// where 6r² - 15r⁴ = 0.5.
+	inner := math.Sqrt((6 - math.Sqrt(6)) / 30)
+
+	single := Blob(0.5, BlobComponent{Center: Point(0, 0, 0), Radius: 1, Strength: 1})
+	pair := Blob(0.5,
+		BlobComponent{Center: Point(-0.5, 0, 0), Radius: 1, Strength: 1},
+		BlobComponent{Center: Point(0.5, 0, 0), Radius: 1, Strength: 1},
+	)
+	hollow := Blob(0.5,
+		BlobComponent{Center: Point(0, 0, 0), Radius: 1, Strength: 1},
+		BlobComponent{Center: Point(0, 0, 0), Radius: 0.5, Strength: -1},
+	)
+	large := Blob(0.5,
+		BlobComponent{Center: Point(-250, 0, 0), Radius: 500, Strength: 1},
+		BlobComponent{Center: Point(250, 0, 0), Radius: 500, Strength: 1},
+	)
+
+	tests := []struct {
+		name   string
+		b      *BlobT
+		ray    RayT
+		wantTs []float64
+	}{
+		{
+			name:   "A ray through a single component",
+			b:      single,
+			ray:    Ray(Point(0, 0, -5), Vector(0, 0, 1)),
+			wantTs: []float64{5 - r, 5 + r},
+		},
+		{
+			name:   "A ray with a scaled direction",
+			b:      single,
+			ray:    Ray(Point(0, 0, -5), Vector(0, 0, 2)),
+			wantTs: []float64{(5 - r) / 2, (5 + r) / 2},
+		},
+		{
+			name: "A ray inside the influence but outside the surface",
+			b:    single,
+			ray:  Ray(Point(0, 0.8, -5), Vector(0, 0, 1)),
+		},
+		{
+			name: "A ray missing the blob",
+			b:    single,
+			ray:  Ray(Point(0, 2, -5), Vector(0, 0, 1)),
+		},
+		{
+			name:   "A ray along two blended components",
+			b:      pair,
+			ray:    Ray(Point(-5, 0, 0), Vector(1, 0, 0)),
+			wantTs: []float64{4.5 - r, 5.5 + r},
+		},
+		{
+			name:   "A ray through the neck of two blended components",
+			b:      pair,
+			ray:    Ray(Point(0, -5, 0), Vector(0, 1, 0)),
+			wantTs: []float64{4.5, 5.5},
+		},
+		{
+			name:   "A ray through a component with a negative component inside",
+			b:      hollow,
+			ray:    Ray(Point(0, 0, -5), Vector(0, 0, 1)),
+			wantTs: []float64{5 - r, 5 - inner, 5 + inner, 5 + r},
+		},
+		{
+			name:   "A ray along two large blended components",
+			b:      large,
+			ray:    Ray(Point(-5000, 0, 0), Vector(1, 0, 0)),
+			wantTs: []float64{4750 - 500*r, 5250 + 500*r},
+		},
+		{
+			name:   "A ray through a single large component",
+			b:      Blob(0.5, BlobComponent{Center: Point(0, 0, 0), Radius: 200, Strength: 1}),
+			ray:    Ray(Point(0, 0, -500), Vector(0, 0, 1)),
+			wantTs: []float64{500 - 200*r, 500 + 200*r},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.b.LocalIntersect(tt.ray)
+			if len(got) != len(tt.wantTs) {
+				t.Fatalf("LocalIntersect = %v, want %v intersections", got, len(tt.wantTs))
+			}
+			for i, want := range tt.wantTs {
+				if math.Abs(got[i].T-want) > epsilon {
+					t.Errorf("LocalIntersect[%v].T = %v, want %v", i, got[i].T, want)
+				}
+				if got[i].Object != tt.b {
+					t.Errorf("LocalIntersect[%v].Object = %v, want %v", i, got[i].Object, tt.b)
+				}
+			}
+		})
+	}
+}
+
+func TestBlobT_LocalNormalAt(t *testing.T) {
+	r := math.Sqrt(1 - math.Sqrt(0.5))
+	single := Blob(0.5, BlobComponent{Center: Point(0, 0, 0), Radius: 1, Strength: 1})
+	pair := Blob(0.5,
+		BlobComponent{Center: Point(-0.5, 0, 0), Radius: 1, Strength: 1},
+		BlobComponent{Center: Point(0.5, 0, 0), Radius: 1, Strength: 1},
+	)
+
+	tests := []struct {
+		name  string
+		b     *BlobT
+		point Tuple
+		want  Tuple
+	}{
+		{name: "single front", b: single, point: Point(0, 0, -r), want: Vector(0, 0, -1)},
+		{name: "single top", b: single, point: Point(0, r, 0), want: Vector(0, 1, 0)},
+		{name: "pair neck", b: pair, point: Point(0, 0.5, 0), want: Vector(0, 1, 0)},
+		{name: "pair end", b: pair, point: Point(0.5+r, 0, 0), want: Vector(1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.LocalNormalAt(tt.point, nil).Normalize(); !got.Equal(tt.want) {
+				t.Errorf("LocalNormalAt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlobT_Bounds(t *testing.T) {
+	b := Blob(0.5,
+		BlobComponent{Center: Point(-1, 0, 0), Radius: 1, Strength: 1},
+		BlobComponent{Center: Point(1, 2, 0), Radius: 0.5, Strength: 2},
+		BlobComponent{Center: Point(5, 5, 5), Radius: 1, Strength: -1},
+	)
+
+	got := b.Bounds()
+	if want := Point(-2, -1, -1); !got.Min.Equal(want) {
+		t.Errorf("Bounds.Min = %v, want %v", got.Min, want)
+	}
+	if want := Point(1.5, 2.5, 1); !got.Max.Equal(want) {
+		t.Errorf("Bounds.Max = %v, want %v", got.Max, want)
+	}
+}
+
+func TestBlobT_Field(t *testing.T) {
+	b := Blob(0.5,
+		BlobComponent{Center: Point(0, 0, 0), Radius: 2, Strength: 1},
+		BlobComponent{Center: Point(1, 0, 0), Radius: 1, Strength: 0.5},
+	)
+
+	tests := []struct {
+		point Tuple
+		want  float64
+	}{
+		{point: Point(0, 0, 0), want: 1},
+		{point: Point(1, 0, 0), want: 0.5625 + 0.5},
+		{point: Point(0, 0, 3), want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := b.Field(tt.point); math.Abs(got-tt.want) > epsilon {
+			t.Errorf("Field(%v) = %v, want %v", tt.point, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package rtc
+
+import "math"
+
+// BumpMap is implemented by materials' normal perturbations, which add
+// surface detail (bumps, dents, scratches, ...) to shading without
+// changing the geometry.
+type BumpMap interface {
+	// PerturbNormal returns the perturbed (unit) object space normal for
+	// the provided hit at the object space point with the unit object
+	// space normal returned by the object's LocalNormalAt.
+	PerturbNormal(object Object, localPoint, localNormal Tuple, hit *IntersectionT) Tuple
+}
+
+// TangentFramer is implemented by objects that have texture coordinates
+// and tangent frames, allowing tangent-space normal maps to be applied
+// to them.
+type TangentFramer interface {
+	// LocalTangentFrame returns the texture coordinates (u,v) of the hit
+	// at the object space point along with the object space directions in
+	// which u (the tangent) and v (the bitangent) increase.
+	LocalTangentFrame(localPoint Tuple, hit *IntersectionT) (float64, float64, Tuple, Tuple)
+}
+
+// PatternBumpT perturbs normals using the brightness of a pattern (such
+// as a NoisePattern) as the height of the surface.
+// It implements the BumpMap interface.
+type PatternBumpT struct {
+	Pattern Pattern
+	// Scale is the height of the bumps for a change in brightness of 1,
+	// in the units of the pattern. Negative values produce dents.
+	Scale float64
+}
+
+var _ BumpMap = &PatternBumpT{}
+
+// PatternBump returns a bump map using the brightness of the pattern as
+// the height of the surface, scaled by scale.
+func PatternBump(pattern Pattern, scale float64) *PatternBumpT {
+	return &PatternBumpT{Pattern: pattern, Scale: scale}
+}
+
+// bumpDelta is the distance (in pattern space) used to estimate the
+// gradient of a bump pattern.
+const bumpDelta = 1e-3
+
+// PerturbNormal tilts the normal away from the gradient of the height
+// of the pattern. Procedural bumps need no tangent frame, so they can be
+// applied to any object.
+func (b *PatternBumpT) PerturbNormal(object Object, localPoint, localNormal Tuple, hit *IntersectionT) Tuple {
+	inv := b.Pattern.GetTransform().Inverse()
+	p := inv.MultTuple(localPoint)
+	height := func(dx, dy, dz float64) float64 {
+		return luminance(b.Pattern.LocalPatternAt(p.Add(Vector(dx, dy, dz))))
+	}
+
+	gradient := Vector(
+		height(bumpDelta, 0, 0)-height(-bumpDelta, 0, 0),
+		height(0, bumpDelta, 0)-height(0, -bumpDelta, 0),
+		height(0, 0, bumpDelta)-height(0, 0, -bumpDelta),
+	).DivScalar(2 * bumpDelta)
+	// Gradients transform from pattern space to object space like normals.
+	gradient = inv.Transpose().MultTuple(gradient)
+	gradient[3] = 0
+
+	// Only the part of the gradient along the surface tilts the normal.
+	surface := gradient.Sub(localNormal.MultScalar(gradient.Dot(localNormal)))
+	return localNormal.Sub(surface.MultScalar(b.Scale)).Normalize()
+}
+
+// NormalMapT perturbs normals using a tangent-space normal map image,
+// whose red, green and blue channels hold the components of the normal
+// along the tangent (increasing u), the bitangent (increasing v) and the
+// surface normal, each mapped from [-1,1] to [0,1] (the OpenGL
+// convention). The top of the image is at v=1 and the map repeats
+// outside of [0,1).
+// It only affects objects that implement the TangentFramer interface.
+// It implements the BumpMap interface.
+type NormalMapT struct {
+	Image *Canvas
+	// Strength scales the tilt of the normals, where 0 leaves them
+	// unchanged and 1 uses the normal map as is.
+	Strength float64
+}
+
+var _ BumpMap = &NormalMapT{}
+
+// NormalMap returns a bump map for the tangent-space normal map image.
+func NormalMap(image *Canvas) *NormalMapT {
+	return &NormalMapT{Image: image, Strength: 1}
+}
+
+// PerturbNormal replaces the normal with the one from the normal map,
+// expressed in the tangent frame of the object.
+func (n *NormalMapT) PerturbNormal(object Object, localPoint, localNormal Tuple, hit *IntersectionT) Tuple {
+	framer, ok := unwrapInstanced(object).(TangentFramer)
+	if !ok {
+		return localNormal
+	}
+
+	u, v, tangent, bitangent := framer.LocalTangentFrame(localPoint, hit)
+	// Make the frame orthonormal around the normal while keeping its
+	// handedness.
+	tangent = tangent.Sub(localNormal.MultScalar(tangent.Dot(localNormal)))
+	if tangent.Magnitude() < eqnEpsilon {
+		return localNormal
+	}
+	tangent = tangent.Normalize()
+	b := localNormal.Cross(tangent)
+	if b.Dot(bitangent) < 0 {
+		b = b.Negate()
+	}
+
+	u, v = u-math.Floor(u), v-math.Floor(v)
+	w, h := float64(n.Image.width), float64(n.Image.height)
+	c := n.Image.sample(u*w, (1-v)*h, true)
+	x := (2*c.Red() - 1) * n.Strength
+	y := (2*c.Green() - 1) * n.Strength
+	z := 2*c.Blue() - 1
+
+	perturbed := tangent.MultScalar(x).Add(b.MultScalar(y)).Add(localNormal.MultScalar(z))
+	if perturbed.Magnitude() < eqnEpsilon {
+		return localNormal
+	}
+	return perturbed.Normalize()
+}
+
+// LocalTangentFrame returns the spherical texture coordinates of the
+// point, where u increases eastward around the Y axis starting from the
+// -Z direction and v increases from the south pole to the north pole.
+func (s *SphereT) LocalTangentFrame(localPoint Tuple, hit *IntersectionT) (float64, float64, Tuple, Tuple) {
+	p := localPoint.Sub(Point(0, 0, 0)).Normalize()
+	x, y, z := p.X(), p.Y(), p.Z()
+
+	theta := math.Atan2(x, z)
+	u := 1 - (theta/(2*math.Pi) + 0.5)
+	v := 1 - math.Acos(math.Max(-1, math.Min(1, y)))/math.Pi
+
+	rho := math.Sqrt(x*x + z*z)
+	if rho < eqnEpsilon {
+		// The poles have no unique tangent.
+		return u, v, Vector(1, 0, 0), Vector(0, 0, -1)
+	}
+	return u, v, Vector(-z, 0, x).DivScalar(rho), Vector(-x*y/rho, rho, -z*y/rho)
+}
+
+// LocalTangentFrame returns the planar texture coordinates of the point,
+// which repeat every unit along X (u) and Z (v).
+func (p *PlaneT) LocalTangentFrame(localPoint Tuple, hit *IntersectionT) (float64, float64, Tuple, Tuple) {
+	u := localPoint.X() - math.Floor(localPoint.X())
+	v := localPoint.Z() - math.Floor(localPoint.Z())
+	return u, v, Vector(1, 0, 0), Vector(0, 0, 1)
+}
+
+// LocalTangentFrame returns the texture coordinates of the point on its
+// face of the cube, where each face is mapped to [0,1] in both u and v
+// as seen from outside the cube, with v increasing upward on the sides,
+// away from the front (-Z) on the bottom and toward it on the top.
+func (c *CubeT) LocalTangentFrame(localPoint Tuple, hit *IntersectionT) (float64, float64, Tuple, Tuple) {
+	x, y, z := localPoint.X(), localPoint.Y(), localPoint.Z()
+	ax, ay, az := math.Abs(x), math.Abs(y), math.Abs(z)
+	coord := func(a float64) float64 { return (a + 1) / 2 }
+
+	switch {
+	case ax >= ay && ax >= az && x > 0:
+		return coord(-z), coord(y), Vector(0, 0, -1), Vector(0, 1, 0)
+	case ax >= ay && ax >= az:
+		return coord(z), coord(y), Vector(0, 0, 1), Vector(0, 1, 0)
+	case ay >= az && y > 0:
+		return coord(x), coord(-z), Vector(1, 0, 0), Vector(0, 0, -1)
+	case ay >= az:
+		return coord(x), coord(z), Vector(1, 0, 0), Vector(0, 0, 1)
+	case z > 0:
+		return coord(x), coord(y), Vector(1, 0, 0), Vector(0, 1, 0)
+	default:
+		return coord(-x), coord(y), Vector(-1, 0, 0), Vector(0, 1, 0)
+	}
+}
+
+// LocalTangentFrame returns the barycentric coordinates of the hit as
+// texture coordinates, so that u increases toward P2 and v toward P3.
+func (t *TriangleT) LocalTangentFrame(localPoint Tuple, hit *IntersectionT) (float64, float64, Tuple, Tuple) {
+	return hit.U, hit.V, t.E1, t.E2
+}
+
+// LocalTangentFrame returns the interpolated texture coordinates of the
+// mesh at the hit along with the tangent frame of the face derived from
+// its texture coordinates. Faces without texture coordinates use their
+// barycentric coordinates instead.
+func (m *MeshT) LocalTangentFrame(localPoint Tuple, hit *IntersectionT) (float64, float64, Tuple, Tuple) {
+	f := &m.Faces[hit.Face]
+	p1 := m.Vertices[f.Vertices[0]]
+	e1 := m.Vertices[f.Vertices[1]].Sub(p1)
+	e2 := m.Vertices[f.Vertices[2]].Sub(p1)
+
+	u, v := m.UVAt(hit)
+	if f.TexCoords[0] < 0 {
+		return u, v, e1, e2
+	}
+
+	t1, t2, t3 := m.TexCoords[f.TexCoords[0]], m.TexCoords[f.TexCoords[1]], m.TexCoords[f.TexCoords[2]]
+	du1, dv1 := t2.X()-t1.X(), t2.Y()-t1.Y()
+	du2, dv2 := t3.X()-t1.X(), t3.Y()-t1.Y()
+	det := du1*dv2 - du2*dv1
+	if math.Abs(det) < eqnEpsilon {
+		return u, v, e1, e2
+	}
+
+	tangent := e1.MultScalar(dv2).Sub(e2.MultScalar(dv1)).DivScalar(det)
+	bitangent := e2.MultScalar(du1).Sub(e1.MultScalar(du2)).DivScalar(det)
+	return u, v, tangent, bitangent
+}
+
+// LocalTangentFrame returns the tangent frame of the mesh at the hit, so
+// that faces with a per-face material can be normal mapped as well.
+func (p *meshPartT) LocalTangentFrame(localPoint Tuple, hit *IntersectionT) (float64, float64, Tuple, Tuple) {
+	return p.mesh.LocalTangentFrame(localPoint, hit)
+}
@@ -0,0 +1,225 @@
+package rtc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPatternBumpT_PerturbNormal(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern Pattern
+		scale   float64
+		point   Tuple
+		normal  Tuple
+		want    Tuple
+	}{
+		{
+			name:    "flat pattern",
+			pattern: StripePattern(Color(1, 1, 1), Color(1, 1, 1)),
+			scale:   1,
+			point:   Point(0.5, 0, 0.5),
+			normal:  Vector(0, 1, 0),
+			want:    Vector(0, 1, 0),
+		},
+		{
+			name:    "height rising along x",
+			pattern: GradientPattern(Color(0, 0, 0), Color(1, 1, 1)),
+			scale:   1,
+			point:   Point(0.5, 0, 0.5),
+			normal:  Vector(0, 1, 0),
+			want:    Vector(-1/math.Sqrt2, 1/math.Sqrt2, 0),
+		},
+		{
+			name:    "dents",
+			pattern: GradientPattern(Color(0, 0, 0), Color(1, 1, 1)),
+			scale:   -1,
+			point:   Point(0.5, 0, 0.5),
+			normal:  Vector(0, 1, 0),
+			want:    Vector(1/math.Sqrt2, 1/math.Sqrt2, 0),
+		},
+		{
+			name:    "gradient along the normal is ignored",
+			pattern: GradientPattern(Color(0, 0, 0), Color(1, 1, 1)),
+			scale:   1,
+			point:   Point(0.5, 0, 0.5),
+			normal:  Vector(1, 0, 0),
+			want:    Vector(1, 0, 0),
+		},
+		{
+			name: "transformed pattern",
+			pattern: func() Pattern {
+				p := GradientPattern(Color(0, 0, 0), Color(1, 1, 1))
+				p.SetTransform(Scaling(2, 1, 1))
+				return p
+			}(),
+			scale:  2,
+			point:  Point(0.5, 0, 0.5),
+			normal: Vector(0, 1, 0),
+			want:   Vector(-1/math.Sqrt2, 1/math.Sqrt2, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bump := PatternBump(tt.pattern, tt.scale)
+			if got := bump.PerturbNormal(Plane(), tt.point, tt.normal, &IntersectionT{}); !got.Equal(tt.want) {
+				t.Errorf("PerturbNormal = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTangentFramer_LocalTangentFrame(t *testing.T) {
+	tri := Triangle(Point(0, 1, 0), Point(-1, 0, 0), Point(1, 0, 0))
+	mesh := Mesh(
+		[]Tuple{Point(0, 0, 0), Point(1, 0, 0), Point(0, 1, 0)},
+		nil,
+		[]Tuple{Point(0, 0, 0), Point(0, 1, 0), Point(-1, 0, 0)},
+		[]MeshFace{{
+			Vertices:  [3]int32{0, 1, 2},
+			Normals:   [3]int32{-1, -1, -1},
+			TexCoords: [3]int32{0, 1, 2},
+			Material:  -1,
+		}},
+	)
+
+	tests := []struct {
+		name          string
+		object        TangentFramer
+		point         Tuple
+		hit           IntersectionT
+		wantU, wantV  float64
+		wantTangent   Tuple
+		wantBitangent Tuple
+	}{
+		{name: "sphere front", object: Sphere(), point: Point(0, 0, -1), wantU: 0, wantV: 0.5, wantTangent: Vector(1, 0, 0), wantBitangent: Vector(0, 1, 0)},
+		{name: "sphere side", object: Sphere(), point: Point(1, 0, 0), wantU: 0.25, wantV: 0.5, wantTangent: Vector(0, 0, 1), wantBitangent: Vector(0, 1, 0)},
+		{name: "sphere north pole", object: Sphere(), point: Point(0, 1, 0), wantU: 0.5, wantV: 1, wantTangent: Vector(1, 0, 0), wantBitangent: Vector(0, 0, -1)},
+		{name: "plane", object: Plane(), point: Point(2.25, 0, -1.5), wantU: 0.25, wantV: 0.5, wantTangent: Vector(1, 0, 0), wantBitangent: Vector(0, 0, 1)},
+		{name: "cube front", object: Cube(), point: Point(-0.5, 0.5, -1), wantU: 0.75, wantV: 0.75, wantTangent: Vector(-1, 0, 0), wantBitangent: Vector(0, 1, 0)},
+		{name: "cube right", object: Cube(), point: Point(1, 0, 0.5), wantU: 0.25, wantV: 0.5, wantTangent: Vector(0, 0, -1), wantBitangent: Vector(0, 1, 0)},
+		{name: "cube top", object: Cube(), point: Point(0.5, 1, 0.5), wantU: 0.75, wantV: 0.25, wantTangent: Vector(1, 0, 0), wantBitangent: Vector(0, 0, -1)},
+		{name: "triangle", object: tri, point: Point(0, 0.5, 0), hit: IntersectionT{U: 0.25, V: 0.25}, wantU: 0.25, wantV: 0.25, wantTangent: Vector(-1, -1, 0), wantBitangent: Vector(1, -1, 0)},
+		// The texture coordinates of the mesh are rotated a quarter turn
+		// from its vertices.
+		{name: "mesh with texture coordinates", object: mesh, point: Point(0.25, 0.25, 0), hit: IntersectionT{U: 0.25, V: 0.25}, wantU: -0.25, wantV: 0.25, wantTangent: Vector(0, -1, 0), wantBitangent: Vector(1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, v, tangent, bitangent := tt.object.LocalTangentFrame(tt.point, &tt.hit)
+			if math.Abs(u-tt.wantU) > epsilon || math.Abs(v-tt.wantV) > epsilon {
+				t.Errorf("LocalTangentFrame uv = (%v,%v), want (%v,%v)", u, v, tt.wantU, tt.wantV)
+			}
+			if !tangent.Equal(tt.wantTangent) {
+				t.Errorf("LocalTangentFrame tangent = %v, want %v", tangent, tt.wantTangent)
+			}
+			if !bitangent.Equal(tt.wantBitangent) {
+				t.Errorf("LocalTangentFrame bitangent = %v, want %v", bitangent, tt.wantBitangent)
+			}
+		})
+	}
+}
+
+func TestNormalMapT_PerturbNormal(t *testing.T) {
+	tests := []struct {
+		name   string
+		color  Tuple
+		object Object
+		point  Tuple
+		normal Tuple
+		want   Tuple
+	}{
+		{name: "flat map", color: Color(0.5, 0.5, 1), object: Plane(), point: Point(0.5, 0, 0.5), normal: Vector(0, 1, 0), want: Vector(0, 1, 0)},
+		{name: "plane tangent", color: Color(1, 0.5, 0.5), object: Plane(), point: Point(0.5, 0, 0.5), normal: Vector(0, 1, 0), want: Vector(1, 0, 0)},
+		{name: "plane bitangent", color: Color(0.5, 1, 0.5), object: Plane(), point: Point(0.5, 0, 0.5), normal: Vector(0, 1, 0), want: Vector(0, 0, 1)},
+		{name: "cube front bitangent", color: Color(0.5, 1, 0.5), object: Cube(), point: Point(0, 0, -1), normal: Vector(0, 0, -1), want: Vector(0, 1, 0)},
+		{name: "sphere tangent", color: Color(1, 0.5, 0.5), object: Sphere(), point: Point(1, 0, 0), normal: Vector(1, 0, 0), want: Vector(0, 0, 1)},
+		{name: "object without a tangent frame", color: Color(1, 0.5, 0.5), object: Torus(), point: Point(1, 0, 0), normal: Vector(1, 0, 0), want: Vector(1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bump := NormalMap(uniformCanvas(4, 4, tt.color))
+			if got := bump.PerturbNormal(tt.object, tt.point, tt.normal, &IntersectionT{}); !got.Equal(tt.want) {
+				t.Errorf("PerturbNormal = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersectionT_NormalAt_Bump(t *testing.T) {
+	s := Sphere()
+	s.SetTransform(Translation(0, 1, 0))
+	s.GetMaterial().Bump = NormalMap(uniformCanvas(2, 2, Color(0.5, 1, 0.5)))
+
+	// Straight ahead on the equator, the bitangent points up.
+	hit := Intersection(4, s)
+	if got, want := hit.NormalAt(Point(0, 1, -1)), Vector(0, 1, 0); !got.Equal(want) {
+		t.Errorf("NormalAt = %v, want %v", got, want)
+	}
+
+	s.GetMaterial().Bump = nil
+	if got, want := hit.NormalAt(Point(0, 1, -1)), Vector(0, 0, -1); !got.Equal(want) {
+		t.Errorf("NormalAt without bump = %v, want %v", got, want)
+	}
+}
+
+func TestIntersectionT_NormalAt_MeshNormalMap(t *testing.T) {
+	bumped := GetMaterial()
+	bumped.Bump = NormalMap(uniformCanvas(2, 2, Color(1, 0.5, 0.5)))
+
+	tests := []struct {
+		name         string
+		faceMaterial bool
+	}{
+		{name: "mesh material"},
+		{name: "per-face material", faceMaterial: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			face := MeshTriangle(0, 1, 2)
+			var materials []MaterialT
+			if tt.faceMaterial {
+				face.Material = 0
+				materials = append(materials, bumped)
+			}
+			m := Mesh([]Tuple{Point(0, 0, 0), Point(1, 0, 0), Point(0, 1, 0)}, nil, nil, []MeshFace{face}, materials...)
+			if !tt.faceMaterial {
+				m.SetMaterial(bumped)
+			}
+
+			r := Ray(Point(0.25, 0.25, -5), Vector(0, 0, 1))
+			xs := Intersect(m, r)
+			if len(xs) != 1 {
+				t.Fatalf("Intersect = %v, want 1 intersection", xs)
+			}
+			// The tangent of the face (without texture coordinates) is
+			// along its first edge.
+			if got, want := xs[0].NormalAt(r.Position(xs[0].T)), Vector(1, 0, 0); !got.Equal(want) {
+				t.Errorf("NormalAt = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPerlinNoise(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		x, y, z := float64(i)*0.37, float64(i)*0.71-20, float64(i)*1.13+5
+		t.Run(fmt.Sprintf("test #%v", i), func(t *testing.T) {
+			if got := PerlinNoise(math.Floor(x), math.Floor(y), math.Floor(z)); got != 0 {
+				t.Errorf("PerlinNoise at lattice point = %v, want 0", got)
+			}
+			got := PerlinNoise(x, y, z)
+			if got < -1 || got > 1 {
+				t.Errorf("PerlinNoise(%v,%v,%v) = %v, want in [-1,1]", x, y, z, got)
+			}
+			if d := math.Abs(PerlinNoise(x+1e-6, y, z) - got); d > 1e-5 {
+				t.Errorf("PerlinNoise changed by %v over 1e-6, want continuous", d)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package rtc
 
 import (
+	"fmt"
 	"math"
 	"sync"
 )
@@ -9,6 +10,55 @@ const (
 	maxReflections = 4
 )
 
+// Projection selects how a camera maps pixels to rays.
+type Projection int
+
+const (
+	// PerspectiveProjection is a pinhole camera with the field of view
+	// spanning the larger dimension of the canvas.
+	PerspectiveProjection Projection = iota
+	// OrthographicProjection casts parallel rays from a rectangle
+	// OrthographicWidth wide, as used for architectural elevations.
+	OrthographicProjection
+	// FisheyeProjection is an equidistant fisheye lens where the angle
+	// from the view direction grows linearly with the distance from the
+	// center of the canvas, reaching half the field of view at the edge
+	// of the larger dimension.
+	FisheyeProjection
+	// EquirectangularProjection renders a full 360°x180° panorama where
+	// columns map to longitude and rows map to latitude, centered on the
+	// view direction.
+	EquirectangularProjection
+)
+
+// projectionNames are the names used to select projections by name.
+var projectionNames = map[string]Projection{
+	"perspective":     PerspectiveProjection,
+	"orthographic":    OrthographicProjection,
+	"fisheye":         FisheyeProjection,
+	"equirectangular": EquirectangularProjection,
+}
+
+// ParseProjection returns the projection with the provided name, which is
+// one of "perspective", "orthographic", "fisheye" or "equirectangular".
+func ParseProjection(name string) (Projection, error) {
+	p, ok := projectionNames[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown camera projection %q", name)
+	}
+	return p, nil
+}
+
+// String returns the name of the projection.
+func (p Projection) String() string {
+	for name, v := range projectionNames {
+		if v == p {
+			return name
+		}
+	}
+	return fmt.Sprintf("Projection(%d)", int(p))
+}
+
 // CameraT represents a camera.
 type CameraT struct {
 	HSize       int
@@ -20,6 +70,12 @@ type CameraT struct {
 	HalfHeight  float64
 	NumWorkers  int
 
+	// Projection selects how pixels are mapped to rays.
+	Projection Projection
+	// OrthographicWidth is the width of the view in world units for the
+	// orthographic projection.
+	OrthographicWidth float64
+
 	cached       bool
 	cachedInv    M4 // Inverse of Transform
 	cachedOrigin Tuple
@@ -48,6 +104,7 @@ func Camera(hsize, vsize int, fov float64) *CameraT {
 	}
 
 	c.PixelSize = c.HalfWidth * 2 / float64(c.HSize)
+	c.OrthographicWidth = c.HalfWidth * 2
 
 	return c
 }
@@ -70,6 +127,17 @@ func ViewTransform(from, to, up Tuple) M4 {
 
 // RayForPixel returns a ray for the camera at the given pixel.
 func (c *CameraT) RayForPixel(px, py int) RayT {
+	c.cache()
+
+	switch c.Projection {
+	case OrthographicProjection:
+		return c.orthographicRay(px, py)
+	case FisheyeProjection:
+		return c.directionRay(c.fisheyeDirection(px, py))
+	case EquirectangularProjection:
+		return c.directionRay(c.equirectangularDirection(px, py))
+	}
+
 	// The offset from the edge of the canvas to the pixel's center
 	xoffset := (float64(px) + 0.5) * c.PixelSize
 	yoffset := (float64(py) + 0.5) * c.PixelSize
@@ -82,36 +150,102 @@ func (c *CameraT) RayForPixel(px, py int) RayT {
 	// Using the camera matrix, transform the canvas point and the origin
 	// and then compute the ray's direction vector.
 	// The canvas is at Z = -1.
+	pixel := c.cachedInv.MultTuple(Point(worldX, worldY, -1))
+	direction := pixel.Sub(c.cachedOrigin).Normalize()
+
+	return Ray(c.cachedOrigin, direction)
+}
+
+// cache computes the inverse of the camera transform and the camera
+// origin in world space on first use.
+func (c *CameraT) cache() {
 	if !c.cached {
 		c.cachedInv = c.Transform.Inverse()
 		c.cachedOrigin = c.cachedInv.MultTuple(Point(0, 0, 0))
 		c.cached = true
 	}
-	pixel := c.cachedInv.MultTuple(Point(worldX, worldY, -1))
-	direction := pixel.Sub(c.cachedOrigin).Normalize()
+}
 
-	return Ray(c.cachedOrigin, direction)
+// orthographicRay returns a ray parallel to the view direction starting
+// at the pixel's position on the plane of the camera.
+func (c *CameraT) orthographicRay(px, py int) RayT {
+	pixelSize := c.OrthographicWidth / float64(c.HSize)
+	worldX := c.OrthographicWidth/2 - (float64(px)+0.5)*pixelSize
+	worldY := float64(c.VSize)*pixelSize/2 - (float64(py)+0.5)*pixelSize
+
+	origin := c.cachedInv.MultTuple(Point(worldX, worldY, 0))
+	direction := c.cachedInv.MultTuple(Vector(0, 0, -1)).Normalize()
+	return Ray(origin, direction)
+}
+
+// directionRay returns a ray from the camera's origin in the provided
+// camera space direction.
+func (c *CameraT) directionRay(direction Tuple) RayT {
+	return Ray(c.cachedOrigin, c.cachedInv.MultTuple(direction).Normalize())
+}
+
+// fisheyeDirection returns the camera space direction of the pixel for the
+// fisheye projection.
+func (c *CameraT) fisheyeDirection(px, py int) Tuple {
+	// The offsets from the center of the canvas, with +X to the left.
+	dx := float64(c.HSize)/2 - (float64(px) + 0.5)
+	dy := float64(c.VSize)/2 - (float64(py) + 0.5)
+	halfSize := float64(c.HSize) / 2
+	if c.VSize > c.HSize {
+		halfSize = float64(c.VSize) / 2
+	}
+
+	r := math.Sqrt(dx*dx + dy*dy)
+	if r == 0 {
+		return Vector(0, 0, -1)
+	}
+	theta := r / halfSize * c.FieldOfView / 2
+	s := math.Sin(theta) / r
+	return Vector(dx*s, dy*s, -math.Cos(theta))
+}
+
+// equirectangularDirection returns the camera space direction of the pixel
+// for the equirectangular projection.
+func (c *CameraT) equirectangularDirection(px, py int) Tuple {
+	longitude := 2 * math.Pi * ((float64(px)+0.5)/float64(c.HSize) - 0.5)
+	latitude := math.Pi * (0.5 - (float64(py)+0.5)/float64(c.VSize))
+	cosLat := math.Cos(latitude)
+	return Vector(-math.Sin(longitude)*cosLat, math.Sin(latitude), -math.Cos(longitude)*cosLat)
 }
 
 // Render renders the world with the camera and returns an image.
 func (c *CameraT) Render(world *WorldT) *Canvas {
+	return c.render(world, c.RayForPixel)
+}
+
+// render renders the world with the camera using the provided function to
+// generate the ray for each pixel.
+func (c *CameraT) render(world *WorldT, rayForPixel func(x, y int) RayT) *Canvas {
 	canvas := NewCanvas(c.HSize, c.VSize)
+	c.forEachPixel(rayForPixel, func(x, y int, ray RayT) {
+		canvas.WritePixel(x, y, world.ColorAt(ray, maxReflections))
+	})
+	return canvas
+}
+
+// forEachPixel calls f with the ray for every pixel of the camera, using
+// NumWorkers goroutines when NumWorkers > 1.
+func (c *CameraT) forEachPixel(rayForPixel func(x, y int) RayT, f func(x, y int, ray RayT)) {
+	c.cache()
 
-	f := func(x, y int) {
-		ray := c.RayForPixel(x, y)
-		color := world.ColorAt(ray, maxReflections)
-		canvas.WritePixel(x, y, color)
+	pixel := func(x, y int) {
+		f(x, y, rayForPixel(x, y))
 	}
 
 	var wg sync.WaitGroup
 	if c.NumWorkers > 1 {
 		ch := make(chan struct{}, c.NumWorkers)
-		origF := f
-		f = func(x, y int) {
+		origPixel := pixel
+		pixel = func(x, y int) {
 			wg.Add(1)
 			ch <- struct{}{}
 			go func(x, y int) {
-				origF(x, y)
+				origPixel(x, y)
 				wg.Done()
 				<-ch
 			}(x, y)
@@ -120,13 +254,11 @@ func (c *CameraT) Render(world *WorldT) *Canvas {
 
 	for y := 0; y < c.VSize; y++ {
 		for x := 0; x < c.HSize; x++ {
-			f(x, y)
+			pixel(x, y)
 		}
 	}
 
 	if c.NumWorkers > 1 {
 		wg.Wait()
 	}
-
-	return canvas
 }
@@ -127,6 +127,139 @@ func TestCameraT_RayForPixel(t *testing.T) {
 	}
 }
 
+func TestCameraT_RayForPixel_Projections(t *testing.T) {
+	sq2 := math.Sqrt2 / 2
+
+	tests := []struct {
+		name          string
+		projection    Projection
+		hsize         int
+		vsize         int
+		fov           float64
+		transform     M4
+		x             int
+		y             int
+		wantOrigin    Tuple
+		wantDirection Tuple
+	}{
+		{
+			name:          "orthographic center",
+			projection:    OrthographicProjection,
+			hsize:         201,
+			vsize:         101,
+			transform:     M4Identity(),
+			x:             100,
+			y:             50,
+			wantOrigin:    Point(0, 0, 0),
+			wantDirection: Vector(0, 0, -1),
+		},
+		{
+			name:          "orthographic corner",
+			projection:    OrthographicProjection,
+			hsize:         201,
+			vsize:         101,
+			transform:     M4Identity(),
+			x:             0,
+			y:             0,
+			wantOrigin:    Point(10, 5, 0),
+			wantDirection: Vector(0, 0, -1),
+		},
+		{
+			name:          "orthographic with transformed camera",
+			projection:    OrthographicProjection,
+			hsize:         201,
+			vsize:         101,
+			transform:     RotationY(math.Pi / 4).Mult(Translation(0, -2, 5)),
+			x:             100,
+			y:             50,
+			wantOrigin:    Point(0, 2, -5),
+			wantDirection: Vector(sq2, 0, -sq2),
+		},
+		{
+			name:          "fisheye center",
+			projection:    FisheyeProjection,
+			hsize:         3,
+			vsize:         3,
+			fov:           math.Pi,
+			transform:     M4Identity(),
+			x:             1,
+			y:             1,
+			wantOrigin:    Point(0, 0, 0),
+			wantDirection: Vector(0, 0, -1),
+		},
+		{
+			name:          "fisheye left edge",
+			projection:    FisheyeProjection,
+			hsize:         3,
+			vsize:         3,
+			fov:           math.Pi,
+			transform:     M4Identity(),
+			x:             0,
+			y:             1,
+			wantOrigin:    Point(0, 0, 0),
+			wantDirection: Vector(math.Sqrt(3)/2, 0, -0.5),
+		},
+		{
+			name:          "equirectangular upper left",
+			projection:    EquirectangularProjection,
+			hsize:         4,
+			vsize:         2,
+			transform:     M4Identity(),
+			x:             1,
+			y:             0,
+			wantOrigin:    Point(0, 0, 0),
+			wantDirection: Vector(0.5, sq2, -0.5),
+		},
+		{
+			name:          "equirectangular lower right behind",
+			projection:    EquirectangularProjection,
+			hsize:         4,
+			vsize:         2,
+			transform:     M4Identity(),
+			x:             3,
+			y:             1,
+			wantOrigin:    Point(0, 0, 0),
+			wantDirection: Vector(-0.5, -sq2, 0.5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Camera(tt.hsize, tt.vsize, tt.fov)
+			c.Transform = tt.transform
+			c.Projection = tt.projection
+			if tt.projection == OrthographicProjection {
+				c.OrthographicWidth = 20.1
+			}
+			r := c.RayForPixel(tt.x, tt.y)
+
+			if !r.Origin.Equal(tt.wantOrigin) {
+				t.Errorf("Origin = %v, want %v", r.Origin, tt.wantOrigin)
+			}
+
+			if !r.Direction.Equal(tt.wantDirection) {
+				t.Errorf("Direction = %v, want %v", r.Direction, tt.wantDirection)
+			}
+		})
+	}
+}
+
+func TestParseProjection(t *testing.T) {
+	for _, want := range []Projection{PerspectiveProjection, OrthographicProjection, FisheyeProjection, EquirectangularProjection} {
+		got, err := ParseProjection(want.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("ParseProjection(%q) = %v, want %v", want.String(), got, want)
+		}
+	}
+
+	if _, err := ParseProjection("bogus"); err == nil {
+		t.Errorf("ParseProjection(bogus) = nil error, want error")
+	}
+}
+
 func TestViewTransform(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1,13 +1,18 @@
 package rtc
 
 import (
+	"bufio"
 	"fmt"
 	"image"
 	"image/color"
+	_ "image/jpeg" // register JPEG decoder for ReadCanvasFile
 	"image/png"
+	"io"
 	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -139,3 +144,238 @@ func (c *Canvas) WritePPMFile(filename string) error {
 	ppm := c.ToPPM()
 	return ioutil.WriteFile(filename, []byte(ppm), 0644)
 }
+
+// CanvasFromImage returns a new canvas containing the pixels of the image.
+func CanvasFromImage(img image.Image) *Canvas {
+	bounds := img.Bounds()
+	c := NewCanvas(bounds.Dx(), bounds.Dy())
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			c.WritePixel(x, y, Color(float64(r)/65535, float64(g)/65535, float64(b)/65535))
+		}
+	}
+	return c
+}
+
+// CanvasFromPPM parses a plain (P3) or raw (P6) PPM image and returns
+// a new canvas.
+func CanvasFromPPM(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+
+	// readToken returns the next whitespace-delimited token, skipping comments.
+	readToken := func() (string, error) {
+		var token []byte
+		for {
+			b, err := br.ReadByte()
+			if err == io.EOF && len(token) > 0 {
+				return string(token), nil
+			}
+			if err != nil {
+				return "", err
+			}
+			switch {
+			case b == '#' && len(token) == 0:
+				if _, err := br.ReadString('\n'); err != nil {
+					return "", err
+				}
+			case b == ' ' || b == '\t' || b == '\n' || b == '\r':
+				if len(token) > 0 {
+					return string(token), nil
+				}
+			default:
+				token = append(token, b)
+			}
+		}
+	}
+
+	readInt := func() (int, error) {
+		token, err := readToken()
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(token)
+	}
+
+	magic, err := readToken()
+	if err != nil {
+		return nil, err
+	}
+	if magic != "P3" && magic != "P6" {
+		return nil, fmt.Errorf("unsupported PPM magic number %q", magic)
+	}
+
+	width, err := readInt()
+	if err != nil {
+		return nil, err
+	}
+	height, err := readInt()
+	if err != nil {
+		return nil, err
+	}
+	maxValue, err := readInt()
+	if err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 || maxValue <= 0 || maxValue > 65535 {
+		return nil, fmt.Errorf("invalid PPM header: %vx%v, max value %v", width, height, maxValue)
+	}
+
+	readValue := readInt
+	if magic == "P6" {
+		readValue = func() (int, error) {
+			if maxValue < 256 {
+				b, err := br.ReadByte()
+				return int(b), err
+			}
+			var buf [2]byte
+			if _, err := io.ReadFull(br, buf[:]); err != nil {
+				return 0, err
+			}
+			return int(buf[0])<<8 | int(buf[1]), nil
+		}
+	}
+
+	scale := 1 / float64(maxValue)
+	c := NewCanvas(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var rgb [3]int
+			for i := range rgb {
+				if rgb[i], err = readValue(); err != nil {
+					return nil, err
+				}
+			}
+			c.WritePixel(x, y, Color(float64(rgb[0])*scale, float64(rgb[1])*scale, float64(rgb[2])*scale))
+		}
+	}
+
+	return c, nil
+}
+
+// CanvasFromHDR parses a Radiance RGBE (.hdr) high dynamic range image
+// and returns a new canvas. Colors are not clamped, so they may exceed 1.
+// Only the standard "-Y height +X width" orientation is supported.
+func CanvasFromHDR(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+
+	line, err := br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "#?") {
+		return nil, fmt.Errorf("unsupported HDR magic number %q", strings.TrimSpace(line))
+	}
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT=32-bit_rle_rgbe" {
+			return nil, fmt.Errorf("unsupported HDR format %q", line)
+		}
+	}
+
+	line, err = br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	var width, height int
+	if _, err := fmt.Sscanf(line, "-Y %d +X %d", &height, &width); err != nil {
+		return nil, fmt.Errorf("unsupported HDR resolution %q", strings.TrimSpace(line))
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid HDR resolution: %vx%v", width, height)
+	}
+
+	c := NewCanvas(width, height)
+	scanline := make([]byte, 4*width)
+	for y := 0; y < height; y++ {
+		if err := readHDRScanline(br, scanline, width); err != nil {
+			return nil, err
+		}
+		for x := 0; x < width; x++ {
+			rgbe := scanline[4*x : 4*x+4]
+			if rgbe[3] == 0 {
+				continue
+			}
+			f := math.Ldexp(1, int(rgbe[3])-(128+8))
+			c.WritePixel(x, y, Color(float64(rgbe[0])*f, float64(rgbe[1])*f, float64(rgbe[2])*f))
+		}
+	}
+
+	return c, nil
+}
+
+// readHDRScanline reads one scanline of RGBE pixels, which is either flat
+// or run-length encoded one component at a time.
+func readHDRScanline(br *bufio.Reader, scanline []byte, width int) error {
+	if _, err := io.ReadFull(br, scanline[:4]); err != nil {
+		return err
+	}
+	if width < 8 || width > 0x7fff || scanline[0] != 2 || scanline[1] != 2 || scanline[2]&0x80 != 0 {
+		_, err := io.ReadFull(br, scanline[4:])
+		return err
+	}
+	if n := int(scanline[2])<<8 | int(scanline[3]); n != width {
+		return fmt.Errorf("HDR scanline width %v, want %v", n, width)
+	}
+
+	for component := 0; component < 4; component++ {
+		for x := 0; x < width; {
+			count, err := br.ReadByte()
+			if err != nil {
+				return err
+			}
+			run := count > 128
+			if run {
+				count -= 128
+			}
+			if count == 0 || x+int(count) > width {
+				return fmt.Errorf("invalid HDR scanline run length %v", count)
+			}
+
+			value, err := br.ReadByte()
+			if err != nil {
+				return err
+			}
+			for i := 0; i < int(count); i++ {
+				if !run && i > 0 {
+					if value, err = br.ReadByte(); err != nil {
+						return err
+					}
+				}
+				scanline[4*x+component] = value
+				x++
+			}
+		}
+	}
+	return nil
+}
+
+// ReadCanvasFile reads a PPM, HDR, PNG, or JPEG image file and returns a
+// new canvas.
+func ReadCanvasFile(filename string) (*Canvas, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch ext := filepath.Ext(filename); {
+	case strings.EqualFold(ext, ".ppm"):
+		return CanvasFromPPM(f)
+	case strings.EqualFold(ext, ".hdr"):
+		return CanvasFromHDR(f)
+	}
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	return CanvasFromImage(img), nil
+}
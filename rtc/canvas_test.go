@@ -110,3 +110,165 @@ func TestCanvas_Ends_In_Newline(t *testing.T) {
 		t.Errorf("ToPPM last character should be newline but got: %v", got)
 	}
 }
+
+func TestCanvasFromPPM(t *testing.T) {
+	tests := []struct {
+		name string
+		ppm  string
+		want [][]Tuple
+	}{
+		{
+			name: "plain PPM with comments",
+			ppm: `P3
+# a comment
+2 2
+# another comment
+255
+255 0 0  0 255 0
+0 0 255  51 102 153
+`,
+			want: [][]Tuple{
+				{Color(1, 0, 0), Color(0, 1, 0)},
+				{Color(0, 0, 1), Color(0.2, 0.4, 0.6)},
+			},
+		},
+		{
+			name: "plain PPM with a different max value",
+			ppm:  "P3\n1 1\n100\n50 25 100\n",
+			want: [][]Tuple{
+				{Color(0.5, 0.25, 1)},
+			},
+		},
+		{
+			name: "raw PPM",
+			ppm:  "P6\n2 1\n255\n\xff\x00\x00\x00\x00\xff",
+			want: [][]Tuple{
+				{Color(1, 0, 0), Color(0, 0, 1)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := CanvasFromPPM(strings.NewReader(tt.ppm))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for y, row := range tt.want {
+				for x, want := range row {
+					if got := c.PixelAt(x, y); !got.Equal(want) {
+						t.Errorf("pixel (%v,%v) = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCanvasFromPPM_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		ppm  string
+	}{
+		{name: "unsupported magic number", ppm: "P5\n1 1\n255\n0\n"},
+		{name: "invalid header", ppm: "P3\n0 1\n255\n"},
+		{name: "truncated pixel data", ppm: "P3\n2 1\n255\n255 0 0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CanvasFromPPM(strings.NewReader(tt.ppm)); err == nil {
+				t.Errorf("CanvasFromPPM = nil error, want error")
+			}
+		})
+	}
+}
+
+func TestCanvasFromPPM_RoundTrip(t *testing.T) {
+	c := NewCanvas(3, 2)
+	c.WritePixel(0, 0, Color(1, 0, 0))
+	c.WritePixel(1, 0, Color(0, 0.2, 0))
+	c.WritePixel(2, 1, Color(0.6, 0.6, 1))
+
+	got, err := CanvasFromPPM(strings.NewReader(c.ToPPM()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			if g, w := got.PixelAt(x, y), c.PixelAt(x, y); !g.Equal(w) {
+				t.Errorf("pixel (%v,%v) = %v, want %v", x, y, g, w)
+			}
+		}
+	}
+}
+
+func TestCanvasFromHDR(t *testing.T) {
+	header := "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n"
+
+	// A flat 2x1 image: 0.5 red (128 * 2^-8) and 4 (128 * 2^-5) white.
+	flat := header + "-Y 1 +X 2\n" + string([]byte{128, 0, 0, 128, 128, 128, 128, 131})
+
+	// An RLE 8x1 image: a run of 8 red values of 1, then literal green and
+	// blue components, then a run of exponents.
+	rle := header + "-Y 1 +X 8\n" + string([]byte{
+		2, 2, 0, 8,
+		128 + 8, 128,
+		8, 0, 0, 0, 0, 128, 128, 128, 128,
+		128 + 8, 0,
+		128 + 8, 129,
+	})
+
+	tests := []struct {
+		name string
+		hdr  string
+		want []Tuple
+	}{
+		{name: "flat", hdr: flat, want: []Tuple{Color(0.5, 0, 0), Color(4, 4, 4)}},
+		{
+			name: "run-length encoded",
+			hdr:  rle,
+			want: []Tuple{
+				Color(1, 0, 0), Color(1, 0, 0), Color(1, 0, 0), Color(1, 0, 0),
+				Color(1, 1, 0), Color(1, 1, 0), Color(1, 1, 0), Color(1, 1, 0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := CanvasFromHDR(strings.NewReader(tt.hdr))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.width != len(tt.want) || c.height != 1 {
+				t.Fatalf("canvas size = %vx%v, want %vx1", c.width, c.height, len(tt.want))
+			}
+			for x, want := range tt.want {
+				if got := c.PixelAt(x, 0); !got.Equal(want) {
+					t.Errorf("pixel (%v,0) = %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCanvasFromHDR_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		hdr  string
+	}{
+		{name: "unsupported magic number", hdr: "P3\n1 1\n255\n"},
+		{name: "unsupported format", hdr: "#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n"},
+		{name: "unsupported orientation", hdr: "#?RADIANCE\n\n+Y 1 +X 1\n"},
+		{name: "truncated pixel data", hdr: "#?RADIANCE\n\n-Y 1 +X 2\n\x80\x80\x80\x80"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CanvasFromHDR(strings.NewReader(tt.hdr)); err == nil {
+				t.Errorf("CanvasFromHDR = nil error, want error")
+			}
+		})
+	}
+}
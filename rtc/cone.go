@@ -8,10 +8,12 @@ import (
 // It implements the Object interface.
 func Cone() *ConeT {
 	return &ConeT{
-		Shape:   Shape{Transform: M4Identity(), Material: GetMaterial()},
-		Minimum: math.Inf(-1),
-		Maximum: math.Inf(1),
-		Closed:  false,
+		Shape:      Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Minimum:    math.Inf(-1),
+		Maximum:    math.Inf(1),
+		Closed:     false,
+		StartAngle: 0,
+		EndAngle:   2 * math.Pi,
 	}
 }
 
@@ -20,7 +22,25 @@ type ConeT struct {
 	Shape
 	Minimum float64
 	Maximum float64
-	Closed  bool
+	// Closed caps both ends and, for partial sweeps, closes the cut faces.
+	Closed bool
+
+	// BottomRadius and TopRadius are the radii at Minimum and Maximum,
+	// which turn the cone into a single frustum and require finite Minimum
+	// and Maximum values. When both are zero (the default), the cone is
+	// the double-napped unit cone whose radius at height y is |y|.
+	BottomRadius float64
+	TopRadius    float64
+
+	// BottomClosed and TopClosed cap a single end of the cone.
+	BottomClosed bool
+	TopClosed    bool
+
+	// StartAngle and EndAngle limit the sweep of the cone around the
+	// Y axis to a pie slice, in radians measured from the +X axis toward
+	// the +Z axis.
+	StartAngle float64
+	EndAngle   float64
 }
 
 var _ Object = &ConeT{}
@@ -33,7 +53,7 @@ func (c *ConeT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (c *ConeT) SetMaterial(material MaterialT) Object {
-	c.Material = material
+	c.setMaterial(material)
 	return c
 }
 
@@ -46,25 +66,53 @@ func (c *ConeT) SetParent(parent Object) Object {
 // Bounds returns the minimum bounding box of the object in object
 // (untransformed) space.
 func (c *ConeT) Bounds() *BoundsT {
+	r := math.Max(math.Abs(c.Minimum), math.Abs(c.Maximum))
+	if !c.isUnit() {
+		r = math.Max(c.BottomRadius, c.TopRadius)
+	}
 	return &BoundsT{
-		Min: Point(c.Minimum, c.Minimum, c.Minimum),
-		Max: Point(c.Maximum, c.Maximum, c.Maximum),
+		Min: Point(-r, c.Minimum, -r),
+		Max: Point(r, c.Maximum, r),
+	}
+}
+
+// isUnit reports whether the cone is the double-napped unit cone rather
+// than a frustum with explicit radii.
+func (c *ConeT) isUnit() bool {
+	return c.BottomRadius == 0 && c.TopRadius == 0
+}
+
+// radiusAt returns the radius of the cone at height y.
+func (c *ConeT) radiusAt(y float64) float64 {
+	if c.isUnit() {
+		return math.Abs(y)
 	}
+	a, k := frustumSlope(c.Minimum, c.Maximum, c.BottomRadius, c.TopRadius)
+	return a + k*y
+}
+
+// inSweep reports whether the point lies within the sweep of the cone.
+func (c *ConeT) inSweep(p Tuple) bool {
+	return sweepIncludes(p.X(), p.Z(), c.StartAngle, c.EndAngle)
 }
 
 func (c *ConeT) intersectCaps(ray RayT, xs []IntersectionT) []IntersectionT {
-	if !c.Closed || math.Abs(ray.Direction.Y()) < epsilon {
+	if math.Abs(ray.Direction.Y()) < epsilon {
 		return xs
 	}
 
-	t := (c.Minimum - ray.Origin.Y()) / ray.Direction.Y()
-	if checkCap(ray, t, c.Minimum) { // Abs not necessary for radius here.
-		xs = append(xs, Intersection(t, c))
+	if c.Closed || c.BottomClosed {
+		t := (c.Minimum - ray.Origin.Y()) / ray.Direction.Y()
+		if checkCap(ray, t, c.radiusAt(c.Minimum)) && c.inSweep(ray.Position(t)) {
+			xs = append(xs, Intersection(t, c))
+		}
 	}
 
-	t = (c.Maximum - ray.Origin.Y()) / ray.Direction.Y()
-	if checkCap(ray, t, c.Maximum) { // Abs not necessary for radius here.
-		xs = append(xs, Intersection(t, c))
+	if c.Closed || c.TopClosed {
+		t := (c.Maximum - ray.Origin.Y()) / ray.Direction.Y()
+		if checkCap(ray, t, c.radiusAt(c.Maximum)) && c.inSweep(ray.Position(t)) {
+			xs = append(xs, Intersection(t, c))
+		}
 	}
 
 	return xs
@@ -73,6 +121,37 @@ func (c *ConeT) intersectCaps(ray RayT, xs []IntersectionT) []IntersectionT {
 // LocalIntersect returns a slice of IntersectionT values where the
 // transformed (object space) ray intersects the object.
 func (c *ConeT) LocalIntersect(ray RayT) []IntersectionT {
+	var xs []IntersectionT
+	if c.isUnit() {
+		xs = c.intersectUnit(ray)
+	} else {
+		a, k := frustumSlope(c.Minimum, c.Maximum, c.BottomRadius, c.TopRadius)
+		for _, t := range intersectFrustum(ray, a, k) {
+			if y := ray.Origin.Y() + t*ray.Direction.Y(); c.Minimum < y && y < c.Maximum {
+				xs = append(xs, Intersection(t, c))
+			}
+		}
+		xs = c.intersectCaps(ray, xs)
+	}
+
+	if c.StartAngle == 0 && c.EndAngle >= 2*math.Pi {
+		return xs
+	}
+
+	result := xs[:0]
+	for _, x := range xs {
+		if c.inSweep(ray.Position(x.T)) {
+			result = append(result, x)
+		}
+	}
+	if c.Closed {
+		result = intersectSweepWalls(ray, c, c.StartAngle, c.EndAngle, c.Minimum, c.Maximum, c.radiusAt, result)
+	}
+	return result
+}
+
+// intersectUnit intersects the ray with the double-napped unit cone.
+func (c *ConeT) intersectUnit(ray RayT) []IntersectionT {
 	a := ray.Direction.X()*ray.Direction.X() - ray.Direction.Y()*ray.Direction.Y() + ray.Direction.Z()*ray.Direction.Z()
 	b := 2*ray.Origin.X()*ray.Direction.X() - 2*ray.Origin.Y()*ray.Direction.Y() + 2*ray.Origin.Z()*ray.Direction.Z()
 	if math.Abs(a) < epsilon && math.Abs(b) < epsilon {
@@ -116,14 +195,23 @@ func (c *ConeT) LocalIntersect(ray RayT) []IntersectionT {
 // LocalNormalAt returns the normal vector at the given point of intersection
 // (transformed to object space) with the object.
 func (c *ConeT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	if n, ok := sweepWallNormal(hit, c.StartAngle, c.EndAngle); ok {
+		return n
+	}
+
 	dist := objectPoint.X()*objectPoint.X() + objectPoint.Z()*objectPoint.Z()
-	if dist < 1 && objectPoint.Y() >= c.Maximum-epsilon {
+	if r := c.radiusAt(c.Maximum); dist < r*r && objectPoint.Y() >= c.Maximum-epsilon {
 		return Vector(0, 1, 0)
 	}
-	if dist < 1 && objectPoint.Y() <= c.Minimum+epsilon {
+	if r := c.radiusAt(c.Minimum); dist < r*r && objectPoint.Y() <= c.Minimum+epsilon {
 		return Vector(0, -1, 0)
 	}
 
+	if !c.isUnit() {
+		_, k := frustumSlope(c.Minimum, c.Maximum, c.BottomRadius, c.TopRadius)
+		return Vector(objectPoint.X(), -k*math.Sqrt(dist), objectPoint.Z())
+	}
+
 	y := math.Sqrt(objectPoint.X()*objectPoint.X() + objectPoint.Z()*objectPoint.Z())
 	if objectPoint.Y() > 0 {
 		y = -y
@@ -216,3 +216,129 @@ func TestConeT_LocalNormalAtWithEndCaps(t *testing.T) {
 		})
 	}
 }
+
+func TestConeT_Bounds(t *testing.T) {
+	tests := []struct {
+		name                    string
+		minimum, maximum        float64
+		bottomRadius, topRadius float64
+		wantMin, wantMax        Tuple
+	}{
+		{
+			name:    "unit cone",
+			minimum: -1,
+			maximum: 2,
+			wantMin: Point(-2, -1, -2),
+			wantMax: Point(2, 2, 2),
+		},
+		{
+			name:         "frustum",
+			minimum:      0,
+			maximum:      3,
+			bottomRadius: 0.5,
+			topRadius:    1.5,
+			wantMin:      Point(-1.5, 0, -1.5),
+			wantMax:      Point(1.5, 3, 1.5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Cone()
+			c.Minimum, c.Maximum = tt.minimum, tt.maximum
+			c.BottomRadius, c.TopRadius = tt.bottomRadius, tt.topRadius
+			b := c.Bounds()
+			if !b.Min.Equal(tt.wantMin) {
+				t.Errorf("Bounds().Min = %v, want %v", b.Min, tt.wantMin)
+			}
+			if !b.Max.Equal(tt.wantMax) {
+				t.Errorf("Bounds().Max = %v, want %v", b.Max, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestConeT_Frustum(t *testing.T) {
+	tests := []struct {
+		name        string
+		topClosed   bool
+		ray         RayT
+		wantT       []float64
+		wantNormals []Tuple
+	}{
+		{
+			name:        "side",
+			ray:         Ray(Point(0, 1, -5), Vector(0, 0, 1)),
+			wantT:       []float64{4.5, 5.5},
+			wantNormals: []Tuple{Vector(0, -1, -2).Normalize(), Vector(0, -1, 2).Normalize()},
+		},
+		{
+			name:        "open top",
+			ray:         Ray(Point(0.25, -5, 0), Vector(0, 1, 0)),
+			wantT:       []float64{5.5},
+			wantNormals: []Tuple{Vector(2, -1, 0).Normalize()},
+		},
+		{
+			name:        "closed top",
+			topClosed:   true,
+			ray:         Ray(Point(0.25, -5, 0), Vector(0, 1, 0)),
+			wantT:       []float64{5.5, 7},
+			wantNormals: []Tuple{Vector(2, -1, 0).Normalize(), Vector(0, 1, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Cone()
+			c.Minimum = 0
+			c.Maximum = 2
+			c.TopRadius = 1
+			c.TopClosed = tt.topClosed
+
+			xs := Intersections(c.LocalIntersect(tt.ray)...)
+			if len(xs) != len(tt.wantT) {
+				t.Fatalf("LocalIntersect = %v, want t = %v", xs, tt.wantT)
+			}
+			for i, want := range tt.wantT {
+				if math.Abs(xs[i].T-want) > epsilon {
+					t.Errorf("xs[%v].T = %v, want %v", i, xs[i].T, want)
+				}
+				n := c.LocalNormalAt(tt.ray.Position(xs[i].T), &xs[i]).Normalize()
+				if !n.Equal(tt.wantNormals[i]) {
+					t.Errorf("normal[%v] = %v, want %v", i, n, tt.wantNormals[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConeT_Sweep(t *testing.T) {
+	c := Cone()
+	c.Minimum = 0
+	c.Maximum = 1
+	c.Closed = true
+	c.StartAngle = math.Pi / 2
+	c.EndAngle = 3 * math.Pi / 2
+
+	// Only the half of the cone with negative x remains.
+	if xs := c.LocalIntersect(Ray(Point(0.5, 5, 0), Vector(0, -1, 0))); len(xs) != 0 {
+		t.Errorf("LocalIntersect = %v, want no hits", xs)
+	}
+
+	r := Ray(Point(-5, 0.5, 0.1), Vector(1, 0, 0))
+	xs := Intersections(c.LocalIntersect(r)...)
+	if len(xs) != 2 {
+		t.Fatalf("LocalIntersect = %v, want 2 hits", xs)
+	}
+	wantT := 5 - math.Sqrt(0.25-0.01)
+	if math.Abs(xs[0].T-wantT) > epsilon {
+		t.Errorf("xs[0].T = %v, want %v", xs[0].T, wantT)
+	}
+	// The ray leaves through the cut face at x = 0.
+	if math.Abs(xs[1].T-5) > epsilon {
+		t.Errorf("xs[1].T = %v, want 5", xs[1].T)
+	}
+	if got, want := c.LocalNormalAt(r.Position(xs[1].T), &xs[1]).Normalize(), Vector(1, 0, 0); !got.Equal(want) {
+		t.Errorf("cut face normal = %v, want %v", got, want)
+	}
+}
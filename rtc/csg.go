@@ -47,7 +47,7 @@ func (c *CSGT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (c *CSGT) SetMaterial(material MaterialT) Object {
-	c.Material = material
+	c.setMaterial(material)
 	return c
 }
 
@@ -23,7 +23,7 @@ func (c *CubeT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (c *CubeT) SetMaterial(material MaterialT) Object {
-	c.Material = material
+	c.setMaterial(material)
 	return c
 }
 
@@ -8,10 +8,14 @@ import (
 // It implements the Object interface.
 func Cylinder() *CylinderT {
 	return &CylinderT{
-		Shape:   Shape{Transform: M4Identity(), Material: GetMaterial()},
-		Minimum: math.Inf(-1),
-		Maximum: math.Inf(1),
-		Closed:  false,
+		Shape:        Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Minimum:      math.Inf(-1),
+		Maximum:      math.Inf(1),
+		Closed:       false,
+		BottomRadius: 1,
+		TopRadius:    1,
+		StartAngle:   0,
+		EndAngle:     2 * math.Pi,
 	}
 }
 
@@ -20,7 +24,24 @@ type CylinderT struct {
 	Shape
 	Minimum float64
 	Maximum float64
-	Closed  bool
+	// Closed caps both ends and, for partial sweeps, closes the cut faces.
+	Closed bool
+
+	// BottomRadius and TopRadius are the radii at Minimum and Maximum.
+	// When they differ the cylinder becomes a frustum (a truncated cone),
+	// which requires finite Minimum and Maximum values.
+	BottomRadius float64
+	TopRadius    float64
+
+	// BottomClosed and TopClosed cap a single end of the cylinder.
+	BottomClosed bool
+	TopClosed    bool
+
+	// StartAngle and EndAngle limit the sweep of the cylinder around the
+	// Y axis to a pie slice, in radians measured from the +X axis toward
+	// the +Z axis.
+	StartAngle float64
+	EndAngle   float64
 }
 
 var _ Object = &CylinderT{}
@@ -33,7 +54,7 @@ func (c *CylinderT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (c *CylinderT) SetMaterial(material MaterialT) Object {
-	c.Material = material
+	c.setMaterial(material)
 	return c
 }
 
@@ -46,12 +67,22 @@ func (c *CylinderT) SetParent(parent Object) Object {
 // Bounds returns the minimum bounding box of the object in object
 // (untransformed) space.
 func (c *CylinderT) Bounds() *BoundsT {
+	r := c.BottomRadius
+	if !math.IsInf(c.Minimum, 0) && !math.IsInf(c.Maximum, 0) {
+		r = math.Max(c.BottomRadius, c.TopRadius)
+	}
 	return &BoundsT{
-		Min: Point(-1, c.Minimum, -1),
-		Max: Point(1, c.Maximum, 1),
+		Min: Point(-r, c.Minimum, -r),
+		Max: Point(r, c.Maximum, r),
 	}
 }
 
+// radiusAt returns the radius of the cylinder at height y.
+func (c *CylinderT) radiusAt(y float64) float64 {
+	a, k := frustumSlope(c.Minimum, c.Maximum, c.BottomRadius, c.TopRadius)
+	return a + k*y
+}
+
 func checkCap(ray RayT, t, radius float64) bool {
 	x := ray.Origin.X() + t*ray.Direction.X()
 	z := ray.Origin.Z() + t*ray.Direction.Z()
@@ -59,57 +90,48 @@ func checkCap(ray RayT, t, radius float64) bool {
 }
 
 func (c *CylinderT) intersectCaps(ray RayT, xs []IntersectionT) []IntersectionT {
-	if !c.Closed || math.Abs(ray.Direction.Y()) < epsilon {
+	if math.Abs(ray.Direction.Y()) < epsilon {
 		return xs
 	}
 
-	t := (c.Minimum - ray.Origin.Y()) / ray.Direction.Y()
-	if checkCap(ray, t, 1) {
-		xs = append(xs, Intersection(t, c))
+	if c.Closed || c.BottomClosed {
+		t := (c.Minimum - ray.Origin.Y()) / ray.Direction.Y()
+		if checkCap(ray, t, c.radiusAt(c.Minimum)) && c.inSweep(ray.Position(t)) {
+			xs = append(xs, Intersection(t, c))
+		}
 	}
 
-	t = (c.Maximum - ray.Origin.Y()) / ray.Direction.Y()
-	if checkCap(ray, t, 1) {
-		xs = append(xs, Intersection(t, c))
+	if c.Closed || c.TopClosed {
+		t := (c.Maximum - ray.Origin.Y()) / ray.Direction.Y()
+		if checkCap(ray, t, c.radiusAt(c.Maximum)) && c.inSweep(ray.Position(t)) {
+			xs = append(xs, Intersection(t, c))
+		}
 	}
 
 	return xs
 }
 
+// inSweep reports whether the point lies within the sweep of the cylinder.
+func (c *CylinderT) inSweep(p Tuple) bool {
+	return sweepIncludes(p.X(), p.Z(), c.StartAngle, c.EndAngle)
+}
+
 // LocalIntersect returns a slice of IntersectionT values where the
 // transformed (object space) ray intersects the object.
 func (c *CylinderT) LocalIntersect(ray RayT) []IntersectionT {
-	a := ray.Direction.X()*ray.Direction.X() + ray.Direction.Z()*ray.Direction.Z()
-	if math.Abs(a) < epsilon {
-		return c.intersectCaps(ray, nil)
-	}
-
-	b := 2*ray.Origin.X()*ray.Direction.X() + 2*ray.Origin.Z()*ray.Direction.Z()
-	c2 := ray.Origin.X()*ray.Origin.X() + ray.Origin.Z()*ray.Origin.Z() - 1
-	discriminant := b*b - 4*a*c2
-
-	if discriminant < 0 {
-		return c.intersectCaps(ray, nil)
-	}
-
-	sr := math.Sqrt(discriminant)
-	t1 := (-b - sr) / (2 * a)
-	t2 := (-b + sr) / (2 * a)
-
-	if t1 > t2 {
-		t1, t2 = t2, t1
-	}
-	y1 := ray.Origin.Y() + t1*ray.Direction.Y()
-	y2 := ray.Origin.Y() + t2*ray.Direction.Y()
+	a, k := frustumSlope(c.Minimum, c.Maximum, c.BottomRadius, c.TopRadius)
 
 	var xs []IntersectionT
-	if c.Minimum < y1 && y1 < c.Maximum {
-		xs = append(xs, Intersection(t1, c))
-	}
-	if c.Minimum < y2 && y2 < c.Maximum {
-		xs = append(xs, Intersection(t2, c))
+	for _, t := range intersectFrustum(ray, a, k) {
+		p := ray.Position(t)
+		if c.Minimum < p.Y() && p.Y() < c.Maximum && c.inSweep(p) {
+			xs = append(xs, Intersection(t, c))
+		}
 	}
 	xs = c.intersectCaps(ray, xs)
+	if c.Closed {
+		xs = intersectSweepWalls(ray, c, c.StartAngle, c.EndAngle, c.Minimum, c.Maximum, c.radiusAt, xs)
+	}
 
 	return xs
 }
@@ -117,15 +139,20 @@ func (c *CylinderT) LocalIntersect(ray RayT) []IntersectionT {
 // LocalNormalAt returns the normal vector at the given point of intersection
 // (transformed to object space) with the object.
 func (c *CylinderT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	if n, ok := sweepWallNormal(hit, c.StartAngle, c.EndAngle); ok {
+		return n
+	}
+
 	dist := objectPoint.X()*objectPoint.X() + objectPoint.Z()*objectPoint.Z()
-	if dist < 1 && objectPoint.Y() >= c.Maximum-epsilon {
+	if r := c.radiusAt(c.Maximum); dist < r*r && objectPoint.Y() >= c.Maximum-epsilon {
 		return Vector(0, 1, 0)
 	}
-	if dist < 1 && objectPoint.Y() <= c.Minimum+epsilon {
+	if r := c.radiusAt(c.Minimum); dist < r*r && objectPoint.Y() <= c.Minimum+epsilon {
 		return Vector(0, -1, 0)
 	}
 
-	return Vector(objectPoint.X(), 0, objectPoint.Z())
+	_, k := frustumSlope(c.Minimum, c.Maximum, c.BottomRadius, c.TopRadius)
+	return Vector(objectPoint.X(), -k*math.Sqrt(dist), objectPoint.Z())
 }
 
 // Includes returns whether this object includes (or actually is) the
@@ -133,3 +160,133 @@ func (c *CylinderT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
 func (c *CylinderT) Includes(other Object) bool {
 	return c == other
 }
+
+// frustumSlope returns the coefficients of the radius a+k*y at height y
+// of a frustum with the provided radii at its minimum and maximum heights.
+// Infinite frustums always have a constant radius.
+func frustumSlope(minimum, maximum, bottomRadius, topRadius float64) (a, k float64) {
+	if bottomRadius == topRadius || math.IsInf(minimum, 0) || math.IsInf(maximum, 0) {
+		return bottomRadius, 0
+	}
+	k = (topRadius - bottomRadius) / (maximum - minimum)
+	return bottomRadius - k*minimum, k
+}
+
+// intersectFrustum returns the ray parameters where the ray intersects the
+// infinite surface around the Y axis whose radius at height y is a+k*y,
+// excluding the mirrored nappe where the radius would be negative.
+// Tangent rays return the same parameter twice.
+func intersectFrustum(ray RayT, a, k float64) []float64 {
+	ox, oy, oz := ray.Origin.X(), ray.Origin.Y(), ray.Origin.Z()
+	dx, dy, dz := ray.Direction.X(), ray.Direction.Y(), ray.Direction.Z()
+
+	// The radius along the ray is ra + rb*t.
+	ra := a + k*oy
+	rb := k * dy
+
+	qa := dx*dx + dz*dz - rb*rb
+	qb := 2 * (ox*dx + oz*dz - ra*rb)
+	qc := ox*ox + oz*oz - ra*ra
+
+	var ts []float64
+	switch {
+	case k == 0 && qa < epsilon:
+		// Rays (nearly) parallel to the axis miss a wall of constant radius.
+		return nil
+	case k != 0 && math.Abs(qa) <= eqnEpsilon*(math.Abs(qb)+math.Abs(qc)):
+		// Rays parallel to the slope of the wall meet it at most once.
+		if qb == 0 {
+			return nil
+		}
+		ts = []float64{-qc / qb}
+	default:
+		discriminant := qb*qb - 4*qa*qc
+		if discriminant < 0 {
+			return nil
+		}
+		sr := math.Sqrt(discriminant)
+		t1, t2 := (-qb-sr)/(2*qa), (-qb+sr)/(2*qa)
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		ts = []float64{t1, t2}
+	}
+
+	result := ts[:0]
+	for _, t := range ts {
+		if ra+rb*t >= -epsilon {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// Face values of the cut faces of partially swept shapes.
+const (
+	sweepStartFace = 1
+	sweepEndFace   = 2
+)
+
+// sweepIncludes reports whether the (x,z) position lies within the sweep
+// from start to end radians around the Y axis.
+func sweepIncludes(x, z, start, end float64) bool {
+	if end-start >= 2*math.Pi {
+		return true
+	}
+	angle := math.Mod(math.Atan2(z, x)-start, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle <= end-start
+}
+
+// intersectSweepWalls appends the intersections of the ray with the flat
+// cut faces at the start and end angles of a partial sweep, bounded by
+// the Y axis, the radius at each height, and the minimum and maximum.
+func intersectSweepWalls(ray RayT, object Object, start, end, minimum, maximum float64, radiusAt func(y float64) float64, xs []IntersectionT) []IntersectionT {
+	if end-start >= 2*math.Pi {
+		return xs
+	}
+
+	walls := []struct {
+		face  int
+		angle float64
+	}{
+		{face: sweepStartFace, angle: start},
+		{face: sweepEndFace, angle: end},
+	}
+
+	for _, wall := range walls {
+		cos, sin := math.Cos(wall.angle), math.Sin(wall.angle)
+		// The wall lies in the plane through the Y axis with this normal.
+		denom := ray.Direction.Z()*cos - ray.Direction.X()*sin
+		if math.Abs(denom) < epsilon {
+			continue
+		}
+		t := (ray.Origin.X()*sin - ray.Origin.Z()*cos) / denom
+		p := ray.Position(t)
+		if p.Y() <= minimum || p.Y() >= maximum {
+			continue
+		}
+		if along := p.X()*cos + p.Z()*sin; along >= 0 && along <= radiusAt(p.Y()) {
+			xs = append(xs, IntersectionT{T: t, Object: object, Face: wall.face})
+		}
+	}
+
+	return xs
+}
+
+// sweepWallNormal returns the outward normal of the cut face that was hit,
+// if any.
+func sweepWallNormal(hit *IntersectionT, start, end float64) (Tuple, bool) {
+	if hit == nil {
+		return Tuple{}, false
+	}
+	switch hit.Face {
+	case sweepStartFace:
+		return Vector(math.Sin(start), 0, -math.Cos(start)), true
+	case sweepEndFace:
+		return Vector(-math.Sin(end), 0, math.Cos(end)), true
+	}
+	return Tuple{}, false
+}
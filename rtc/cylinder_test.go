@@ -231,3 +231,134 @@ func TestCylinderT_LocalNormalAtWithEndCaps(t *testing.T) {
 		})
 	}
 }
+
+func TestCylinderT_Frustum(t *testing.T) {
+	c := Cylinder()
+	c.Minimum = 0
+	c.Maximum = 1
+	c.BottomRadius = 1
+	c.TopRadius = 0.5
+	c.Closed = true
+
+	tests := []struct {
+		name        string
+		cylinder    *CylinderT // defaults to the frustum
+		ray         RayT
+		wantT       []float64
+		wantNormals []Tuple
+	}{
+		{
+			name:        "side",
+			ray:         Ray(Point(0, 0.5, -5), Vector(0, 0, 1)),
+			wantT:       []float64{4.25, 5.75},
+			wantNormals: []Tuple{Vector(0, 1, -2).Normalize(), Vector(0, 1, 2).Normalize()},
+		},
+		{
+			name:        "bottom cap and side",
+			ray:         Ray(Point(0.75, -5, 0), Vector(0, 1, 0)),
+			wantT:       []float64{5, 5.5},
+			wantNormals: []Tuple{Vector(0, -1, 0), Vector(2, 1, 0).Normalize()},
+		},
+		{
+			name:        "both caps",
+			ray:         Ray(Point(0.25, -5, 0), Vector(0, 1, 0)),
+			wantT:       []float64{5, 6},
+			wantNormals: []Tuple{Vector(0, -1, 0), Vector(0, 1, 0)},
+		},
+		{
+			name:     "nearly vertical ray inside a unit cylinder",
+			cylinder: Cylinder(),
+			ray:      Ray(Point(0.5, 0, 0), Vector(0.005, 1, 0)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := c
+			if tt.cylinder != nil {
+				c = tt.cylinder
+			}
+			xs := Intersections(c.LocalIntersect(tt.ray)...)
+			if len(xs) != len(tt.wantT) {
+				t.Fatalf("LocalIntersect = %v, want t = %v", xs, tt.wantT)
+			}
+			for i, want := range tt.wantT {
+				if math.Abs(xs[i].T-want) > epsilon {
+					t.Errorf("xs[%v].T = %v, want %v", i, xs[i].T, want)
+				}
+				n := c.LocalNormalAt(tt.ray.Position(xs[i].T), &xs[i]).Normalize()
+				if !n.Equal(tt.wantNormals[i]) {
+					t.Errorf("normal[%v] = %v, want %v", i, n, tt.wantNormals[i])
+				}
+			}
+		})
+	}
+
+	b := c.Bounds()
+	if want := Point(-1, 0, -1); !b.Min.Equal(want) {
+		t.Errorf("Bounds().Min = %v, want %v", b.Min, want)
+	}
+	if want := Point(1, 1, 1); !b.Max.Equal(want) {
+		t.Errorf("Bounds().Max = %v, want %v", b.Max, want)
+	}
+}
+
+func TestCylinderT_Sweep(t *testing.T) {
+	tests := []struct {
+		name        string
+		closed      bool
+		ray         RayT
+		wantT       []float64
+		wantNormals []Tuple
+	}{
+		{
+			name:        "closed slice",
+			closed:      true,
+			ray:         Ray(Point(0.5, 0, -5), Vector(0, 0, 1)),
+			wantT:       []float64{5, 5 + math.Sqrt(0.75)},
+			wantNormals: []Tuple{Vector(0, 0, -1), Vector(0.5, 0, math.Sqrt(0.75))},
+		},
+		{
+			name:        "open slice",
+			ray:         Ray(Point(0.5, 0, -5), Vector(0, 0, 1)),
+			wantT:       []float64{5 + math.Sqrt(0.75)},
+			wantNormals: []Tuple{Vector(0.5, 0, math.Sqrt(0.75))},
+		},
+		{
+			name:   "outside the slice",
+			closed: true,
+			ray:    Ray(Point(-0.5, 0, -5), Vector(0, 0, 1)),
+		},
+		{
+			name:        "closed slice from above",
+			closed:      true,
+			ray:         Ray(Point(0.5, 5, 0.5), Vector(0, -1, 0)),
+			wantT:       []float64{4, 6},
+			wantNormals: []Tuple{Vector(0, 1, 0), Vector(0, -1, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Cylinder()
+			c.Minimum = -1
+			c.Maximum = 1
+			c.Closed = tt.closed
+			c.EndAngle = math.Pi / 2
+
+			xs := Intersections(c.LocalIntersect(tt.ray)...)
+			if len(xs) != len(tt.wantT) {
+				t.Fatalf("LocalIntersect = %v, want t = %v", xs, tt.wantT)
+			}
+			for i, want := range tt.wantT {
+				if math.Abs(xs[i].T-want) > epsilon {
+					t.Errorf("xs[%v].T = %v, want %v", i, xs[i].T, want)
+				}
+				n := c.LocalNormalAt(tt.ray.Position(xs[i].T), &xs[i]).Normalize()
+				if !n.Equal(tt.wantNormals[i]) {
+					t.Errorf("normal[%v] = %v, want %v", i, n, tt.wantNormals[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package rtc
+
+import (
+	"log"
+	"math"
+)
+
+// DenoiserT removes noise from renders made with few samples (such as
+// those with jittered geometry lights, image lights or ambient occlusion)
+// with an edge-avoiding à-trous wavelet filter. The filter blurs the image
+// with increasingly wide kernels while the normal, albedo and depth guide
+// buffers (see RenderPassesT) keep it from blurring across the edges of
+// objects and textures.
+type DenoiserT struct {
+	// Iterations is the number of filter passes. Each pass doubles the
+	// spacing of the kernel taps, so 5 passes reach 2*(1+2+4+8+16) = 62
+	// pixels in each direction, covering 125x125 pixels.
+	Iterations int
+
+	// The sigmas control how quickly the weight of a neighboring pixel
+	// falls off with its difference from the filtered pixel: in color
+	// (which is halved after each pass), in normal, in albedo and in depth
+	// relative to the depth of the filtered pixel. Larger values blur more
+	// and a zero sigma ignores that difference.
+	ColorSigma  float64
+	NormalSigma float64
+	AlbedoSigma float64
+	DepthSigma  float64
+}
+
+// Denoiser returns a denoiser with default settings.
+func Denoiser() *DenoiserT {
+	return &DenoiserT{
+		Iterations:  5,
+		ColorSigma:  0.5,
+		NormalSigma: 0.3,
+		AlbedoSigma: 0.1,
+		DepthSigma:  0.05,
+	}
+}
+
+// atrousKernel is the 1D B3-spline kernel of the à-trous filter.
+var atrousKernel = [5]float64{1.0 / 16, 1.0 / 4, 3.0 / 8, 1.0 / 4, 1.0 / 16}
+
+// Denoise returns a filtered copy of the image. Any of the guide buffers
+// (world normals, albedo and depths as stored by RenderPassesT) may be
+// nil, but they must otherwise be the same size as the image.
+func (d *DenoiserT) Denoise(image, normal, albedo, depth *Canvas) *Canvas {
+	for _, guide := range []*Canvas{normal, albedo, depth} {
+		if guide != nil && (guide.width != image.width || guide.height != image.height) {
+			log.Fatalf("programming error - denoiser guide is %vx%v, want %vx%v", guide.width, guide.height, image.width, image.height)
+		}
+	}
+
+	// weight returns the edge-stopping weight for a squared difference.
+	weight := func(diff2, sigma float64) float64 {
+		if sigma <= 0 {
+			return 1
+		}
+		return math.Exp(-diff2 / (sigma * sigma))
+	}
+	distance2 := func(a, b Tuple) float64 {
+		v := a.Sub(b)
+		return v.X()*v.X() + v.Y()*v.Y() + v.Z()*v.Z()
+	}
+	depthWeight := func(p, q float64) float64 {
+		pInf, qInf := math.IsInf(p, 1), math.IsInf(q, 1)
+		switch {
+		case pInf && qInf:
+			return 1
+		case pInf || qInf:
+			return 0
+		}
+		rel := (p - q) / math.Max(p, epsilon)
+		return weight(rel*rel, d.DepthSigma)
+	}
+
+	w, h := image.width, image.height
+	src := mapCanvas(image, func(v Tuple) Tuple { return v })
+	colorSigma := d.ColorSigma
+	for i, step := 0, 1; i < d.Iterations; i, step = i+1, step*2 {
+		dst := NewCanvas(w, h)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				p := y*w + x
+				sum, total := Color(0, 0, 0), 0.0
+				for j := -2; j <= 2; j++ {
+					qy := y + j*step
+					if qy < 0 || qy >= h {
+						continue
+					}
+					for k := -2; k <= 2; k++ {
+						qx := x + k*step
+						if qx < 0 || qx >= w {
+							continue
+						}
+						q := qy*w + qx
+
+						wt := atrousKernel[j+2] * atrousKernel[k+2]
+						wt *= weight(distance2(src.pixels[p], src.pixels[q]), colorSigma)
+						if normal != nil {
+							wt *= weight(distance2(normal.pixels[p], normal.pixels[q]), d.NormalSigma)
+						}
+						if albedo != nil {
+							wt *= weight(distance2(albedo.pixels[p], albedo.pixels[q]), d.AlbedoSigma)
+						}
+						if depth != nil && d.DepthSigma > 0 {
+							wt *= depthWeight(depth.pixels[p].Red(), depth.pixels[q].Red())
+						}
+
+						sum = sum.Add(src.pixels[q].MultScalar(wt))
+						total += wt
+					}
+				}
+				// The center pixel always has a positive weight.
+				dst.pixels[p] = sum.DivScalar(total)
+			}
+		}
+		src = dst
+		colorSigma /= 2
+	}
+	return src
+}
+
+// Denoise returns the beauty pass filtered by the denoiser, guided by
+// the normal, albedo and depth passes.
+func (r *RenderPassesT) Denoise(d *DenoiserT) *Canvas {
+	return d.Denoise(r.Pass(BeautyPass), r.Pass(NormalPass), r.Pass(AlbedoPass), r.Pass(DepthPass))
+}
@@ -0,0 +1,133 @@
+package rtc
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// noisyCanvas returns a canvas of the provided colors for the left and
+// right halves with deterministic noise of the given amplitude added.
+func noisyCanvas(width, height int, left, right Tuple, amplitude float64) *Canvas {
+	rng := rand.New(rand.NewSource(1))
+	c := NewCanvas(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			color := left
+			if x >= width/2 {
+				color = right
+			}
+			n := amplitude * (2*rng.Float64() - 1)
+			c.WritePixel(x, y, color.Add(Color(n, n, n)))
+		}
+	}
+	return c
+}
+
+// meanAndDeviation returns the mean and standard deviation of the red
+// channel of the pixels in the columns [x0,x1).
+func meanAndDeviation(c *Canvas, x0, x1 int) (float64, float64) {
+	var sum, sum2 float64
+	var n int
+	for y := 0; y < c.height; y++ {
+		for x := x0; x < x1; x++ {
+			v := c.PixelAt(x, y).Red()
+			sum += v
+			sum2 += v * v
+			n++
+		}
+	}
+	mean := sum / float64(n)
+	return mean, math.Sqrt(math.Max(0, sum2/float64(n)-mean*mean))
+}
+
+func TestDenoiserT_Denoise_Constant(t *testing.T) {
+	image := uniformCanvas(8, 8, Color(0.2, 0.4, 0.6))
+	got := Denoiser().Denoise(image, nil, nil, nil)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if v := got.PixelAt(x, y); !v.Equal(Color(0.2, 0.4, 0.6)) {
+				t.Errorf("pixel (%v,%v) = %v, want unchanged", x, y, v)
+			}
+		}
+	}
+}
+
+func TestDenoiserT_Denoise(t *testing.T) {
+	const size = 32
+	black, white := Color(0, 0, 0), Color(1, 1, 1)
+
+	tests := []struct {
+		name      string
+		image     *Canvas
+		normal    *Canvas
+		albedo    *Canvas
+		depth     *Canvas
+		wantLeft  float64
+		wantRight float64
+	}{
+		{
+			name:      "noise is removed",
+			image:     noisyCanvas(size, size, Color(0.5, 0.5, 0.5), Color(0.5, 0.5, 0.5), 0.2),
+			wantLeft:  0.5,
+			wantRight: 0.5,
+		},
+		{
+			name:      "albedo edges are kept",
+			image:     noisyCanvas(size, size, Color(0.3, 0.3, 0.3), Color(0.7, 0.7, 0.7), 0.2),
+			albedo:    noisyCanvas(size, size, black, white, 0),
+			wantLeft:  0.3,
+			wantRight: 0.7,
+		},
+		{
+			name:      "normal edges are kept",
+			image:     noisyCanvas(size, size, Color(0.3, 0.3, 0.3), Color(0.7, 0.7, 0.7), 0.2),
+			normal:    noisyCanvas(size, size, Color(0, 0, -1), Color(1, 0, 0), 0),
+			wantLeft:  0.3,
+			wantRight: 0.7,
+		},
+		{
+			name:      "depth edges are kept",
+			image:     noisyCanvas(size, size, Color(0.3, 0.3, 0.3), Color(0.7, 0.7, 0.7), 0.2),
+			depth:     noisyCanvas(size, size, Color(2, 2, 2), Color(math.Inf(1), math.Inf(1), math.Inf(1)), 0),
+			wantLeft:  0.3,
+			wantRight: 0.7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, noise := meanAndDeviation(tt.image, 0, size/2)
+			got := Denoiser().Denoise(tt.image, tt.normal, tt.albedo, tt.depth)
+
+			for _, half := range []struct {
+				x0, x1 int
+				want   float64
+			}{{0, size / 2, tt.wantLeft}, {size / 2, size, tt.wantRight}} {
+				mean, deviation := meanAndDeviation(got, half.x0, half.x1)
+				if math.Abs(mean-half.want) > 0.02 {
+					t.Errorf("mean of columns [%v,%v) = %v, want %v", half.x0, half.x1, mean, half.want)
+				}
+				if deviation > noise/4 {
+					t.Errorf("deviation of columns [%v,%v) = %v, want well below the noise of %v", half.x0, half.x1, deviation, noise)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderPassesT_Denoise(t *testing.T) {
+	w := DefaultWorld()
+	c := Camera(11, 11, math.Pi/2)
+	c.Transform = ViewTransform(Point(0, 0, -5), Point(0, 0, 0), Vector(0, 1, 0))
+	passes := c.RenderPasses(w)
+
+	got := passes.Denoise(Denoiser())
+	// The background is not blurred into the sphere.
+	if v := got.PixelAt(0, 0); !v.Equal(Color(0, 0, 0)) {
+		t.Errorf("background pixel = %v, want black", v)
+	}
+	if v, want := got.PixelAt(5, 5), passes.Pass(BeautyPass).PixelAt(5, 5); v.Sub(want).Magnitude() > 0.05 {
+		t.Errorf("center pixel = %v, want about %v", v, want)
+	}
+}
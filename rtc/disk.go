@@ -0,0 +1,102 @@
+package rtc
+
+import "math"
+
+// Disk creates a disk of radius 1 at the origin on the X-Z axes and +Y is up.
+// It implements the Object interface.
+func Disk() *DiskT {
+	return &DiskT{
+		Shape:  Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Radius: 1,
+	}
+}
+
+// DiskT represents a Disk.
+type DiskT struct {
+	Shape
+	Radius float64
+}
+
+var _ Object = &DiskT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (d *DiskT) SetTransform(m M4) Object {
+	d.Transform = m
+	return d
+}
+
+// SetMaterial sets the object's material.
+func (d *DiskT) SetMaterial(material MaterialT) Object {
+	d.setMaterial(material)
+	return d
+}
+
+// SetParent sets the object's parent object.
+func (d *DiskT) SetParent(parent Object) Object {
+	d.Parent = parent
+	return d
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (d *DiskT) Bounds() *BoundsT {
+	return &BoundsT{
+		Min: Point(-d.Radius, 0, -d.Radius),
+		Max: Point(d.Radius, 0, d.Radius),
+	}
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+// The U value is the angle around the Y axis (in the range [0,1)) and
+// the V value is the distance from the center relative to the radius.
+func (d *DiskT) LocalIntersect(ray RayT) []IntersectionT {
+	if math.Abs(ray.Direction.Y()) < epsilon {
+		return nil
+	}
+
+	t := -ray.Origin.Y() / ray.Direction.Y()
+	x := ray.Origin.X() + t*ray.Direction.X()
+	z := ray.Origin.Z() + t*ray.Direction.Z()
+	dist := math.Sqrt(x*x + z*z)
+	if dist > d.Radius {
+		return nil
+	}
+
+	return []IntersectionT{IntersectionWithUV(t, d, planarAngle(x, z), dist/d.Radius)}
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+func (d *DiskT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	return Vector(0, 1, 0)
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (d *DiskT) Includes(other Object) bool {
+	return d == other
+}
+
+// SampleSurface maps the provided (u,v) values uniformly to a point on
+// the disk.
+func (d *DiskT) SampleSurface(u, v float64) (Tuple, Object) {
+	r := d.Radius * math.Sqrt(v)
+	phi := 2 * math.Pi * u
+	return Point(r*math.Cos(phi), 0, r*math.Sin(phi)), d
+}
+
+// SurfaceArea returns the area of the disk.
+func (d *DiskT) SurfaceArea() float64 {
+	return math.Pi * d.Radius * d.Radius
+}
+
+// planarAngle returns the angle of the point (x,0,z) around the Y axis,
+// scaled to the range [0,1).
+func planarAngle(x, z float64) float64 {
+	u := math.Atan2(z, x) / (2 * math.Pi)
+	if u < 0 {
+		u++
+	}
+	return u
+}
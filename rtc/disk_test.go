@@ -0,0 +1,79 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDiskT_LocalIntersect(t *testing.T) {
+	d := Disk()
+
+	tests := []struct {
+		name  string
+		ray   RayT
+		want  []IntersectionT
+		wantU float64
+		wantV float64
+	}{
+		{
+			name: "A ray parallel to the disk",
+			ray:  Ray(Point(0, 10, 0), Vector(0, 0, 1)),
+		},
+		{
+			name:  "A ray intersecting the center of the disk from above",
+			ray:   Ray(Point(0, 1, 0), Vector(0, -1, 0)),
+			want:  []IntersectionT{Intersection(1, d)},
+			wantU: 0,
+			wantV: 0,
+		},
+		{
+			name:  "A ray intersecting the disk from below",
+			ray:   Ray(Point(0, -1, 0.5), Vector(0, 1, 0)),
+			want:  []IntersectionT{Intersection(1, d)},
+			wantU: 0.25,
+			wantV: 0.5,
+		},
+		{
+			name: "A ray missing the disk",
+			ray:  Ray(Point(1, 1, 1), Vector(0, -1, 0)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := d.LocalIntersect(tt.ray)
+			if len(got) != len(tt.want) {
+				t.Fatalf("LocalIntersect = %v, want %v", got, tt.want)
+			}
+			for i, w := range tt.want {
+				if got[i].T != w.T || got[i].Object != w.Object {
+					t.Errorf("LocalIntersect[%v] = %v, want %v", i, got[i], w)
+				}
+				if math.Abs(got[i].U-tt.wantU) > epsilon || math.Abs(got[i].V-tt.wantV) > epsilon {
+					t.Errorf("LocalIntersect[%v] UV = (%v,%v), want (%v,%v)", i, got[i].U, got[i].V, tt.wantU, tt.wantV)
+				}
+			}
+		})
+	}
+}
+
+func TestDiskT_LocalNormalAt(t *testing.T) {
+	d := Disk()
+	if got, want := d.LocalNormalAt(Point(0.5, 0, -0.5), nil), Vector(0, 1, 0); !got.Equal(want) {
+		t.Errorf("LocalNormalAt = %v, want %v", got, want)
+	}
+}
+
+func TestDiskT_Bounds(t *testing.T) {
+	d := Disk()
+	d.Radius = 2
+	g := Group(d)
+
+	b := g.Bounds()
+	if got, want := b.Min, Point(-2, 0, -2); !got.Equal(want) {
+		t.Errorf("Bounds.Min = %v, want %v", got, want)
+	}
+	if got, want := b.Max, Point(2, 0, 2); !got.Equal(want) {
+		t.Errorf("Bounds.Max = %v, want %v", got, want)
+	}
+}
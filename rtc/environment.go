@@ -0,0 +1,254 @@
+package rtc
+
+import (
+	"log"
+	"math"
+)
+
+// Environment is implemented by backgrounds that surround the world.
+// Rays that miss every object (including reflected and refracted rays)
+// take on the color of the environment in their direction.
+type Environment interface {
+	// ColorAt returns the color seen in the provided world space
+	// direction, which is a unit vector.
+	ColorAt(direction Tuple) Tuple
+}
+
+// SolidEnvironment returns an environment of a single color.
+func SolidEnvironment(color Tuple) *SolidEnvironmentT {
+	return &SolidEnvironmentT{Color: color}
+}
+
+// SolidEnvironmentT is an environment of a single color.
+type SolidEnvironmentT struct {
+	Color Tuple
+}
+
+var _ Environment = &SolidEnvironmentT{}
+
+// ColorAt returns the color of the environment.
+func (e *SolidEnvironmentT) ColorAt(direction Tuple) Tuple {
+	return e.Color
+}
+
+// GradientEnvironment returns an environment that blends vertically from
+// the bottom color (straight down) to the top color (straight up).
+func GradientEnvironment(bottom, top Tuple) *GradientEnvironmentT {
+	return &GradientEnvironmentT{Bottom: bottom, Top: top}
+}
+
+// GradientEnvironmentT is a vertical gradient environment.
+type GradientEnvironmentT struct {
+	Bottom Tuple
+	Top    Tuple
+}
+
+var _ Environment = &GradientEnvironmentT{}
+
+// ColorAt returns the color of the gradient in the provided direction.
+func (e *GradientEnvironmentT) ColorAt(direction Tuple) Tuple {
+	t := (direction.Y() + 1) / 2
+	return e.Bottom.MultScalar(1 - t).Add(e.Top.MultScalar(t))
+}
+
+// SkyEnvironment returns an analytic daylight sky using the Preetham model
+// for the provided direction toward the sun and atmospheric turbidity
+// (2 for a very clear sky up to about 10 for a hazy one).
+// Sky luminance is relative to the zenith, which has a luminance of
+// Brightness.
+func SkyEnvironment(sunDirection Tuple, turbidity float64) *SkyEnvironmentT {
+	return &SkyEnvironmentT{
+		SunDirection: sunDirection.Normalize(),
+		Turbidity:    turbidity,
+		Brightness:   1,
+		SunRadius:    0.02,
+		SunColor:     Color(20, 19, 17),
+		GroundColor:  Color(0.3, 0.3, 0.3),
+	}
+}
+
+// SkyEnvironmentT is a Preetham daylight sky with a sun disk.
+type SkyEnvironmentT struct {
+	SunDirection Tuple
+	Turbidity    float64
+	Brightness   float64
+
+	// SunRadius is the angular radius of the sun disk in radians, and
+	// SunColor is its color. A zero radius hides the sun disk.
+	SunRadius float64
+	SunColor  Tuple
+
+	// GroundColor is the color below the horizon.
+	GroundColor Tuple
+}
+
+var _ Environment = &SkyEnvironmentT{}
+
+// perezCoefficients are the coefficients of the Perez sky distribution.
+type perezCoefficients [5]float64
+
+// perez evaluates the Perez distribution for a view zenith angle of theta
+// and an angle of gamma between the view and the sun.
+func (p perezCoefficients) perez(cosTheta, gamma float64) float64 {
+	cosGamma := math.Cos(gamma)
+	return (1 + p[0]*math.Exp(p[1]/cosTheta)) * (1 + p[2]*math.Exp(p[3]*gamma) + p[4]*cosGamma*cosGamma)
+}
+
+// ColorAt returns the color of the sky in the provided direction.
+func (e *SkyEnvironmentT) ColorAt(direction Tuple) Tuple {
+	if direction.Y() < 0 {
+		return e.GroundColor
+	}
+
+	gamma := math.Acos(math.Max(-1, math.Min(1, direction.Dot(e.SunDirection))))
+	if e.SunRadius > 0 && gamma < e.SunRadius {
+		return e.SunColor
+	}
+
+	t := e.Turbidity
+	thetaS := math.Acos(math.Max(0, math.Min(1, e.SunDirection.Y())))
+	cosTheta := math.Max(direction.Y(), 1e-3)
+
+	coeffY := perezCoefficients{0.1787*t - 1.4630, -0.3554*t + 0.4275, -0.0227*t + 5.3251, 0.1206*t - 2.5771, -0.0670*t + 0.3703}
+	coeffX := perezCoefficients{-0.0193*t - 0.2592, -0.0665*t + 0.0008, -0.0004*t + 0.2125, -0.0641*t - 0.8989, -0.0033*t + 0.0452}
+	coeffy := perezCoefficients{-0.0167*t - 0.2608, -0.0950*t + 0.0092, -0.0079*t + 0.2102, -0.0441*t - 1.6537, -0.0109*t + 0.0529}
+
+	t2, s, s2, s3 := t*t, thetaS, thetaS*thetaS, thetaS*thetaS*thetaS
+	zenithX := t2*(0.00166*s3-0.00375*s2+0.00209*s) + t*(-0.02903*s3+0.06377*s2-0.03202*s+0.00394) + (0.11693*s3 - 0.21196*s2 + 0.06052*s + 0.25886)
+	zenithY := t2*(0.00275*s3-0.00610*s2+0.00317*s) + t*(-0.04214*s3+0.08970*s2-0.04153*s+0.00516) + (0.15346*s3 - 0.26756*s2 + 0.06670*s + 0.26688)
+
+	// Each value is the zenith value scaled by the Perez distribution
+	// relative to its value at the zenith.
+	relative := func(p perezCoefficients) float64 {
+		return p.perez(cosTheta, gamma) / p.perez(1, thetaS)
+	}
+	x := zenithX * relative(coeffX)
+	y := zenithY * relative(coeffy)
+	luminance := e.Brightness * relative(coeffY)
+
+	return xyYToRGB(x, y, luminance)
+}
+
+// xyYToRGB converts a CIE xyY color to linear sRGB.
+func xyYToRGB(x, y, luminance float64) Tuple {
+	if y <= 0 {
+		return Color(0, 0, 0)
+	}
+	cx := x / y * luminance
+	cz := (1 - x - y) / y * luminance
+	return Color(
+		math.Max(0, 3.2406*cx-1.5372*luminance-0.4986*cz),
+		math.Max(0, -0.9689*cx+1.8758*luminance+0.0415*cz),
+		math.Max(0, 0.0557*cx-0.2040*luminance+1.0570*cz),
+	)
+}
+
+// EquirectangularEnvironment returns an environment from a latitude-longitude
+// panorama, such as one rendered with EquirectangularProjection. The center
+// of the image is in the -Z direction, columns map to longitude and rows
+// map to latitude from straight up (top) to straight down (bottom).
+func EquirectangularEnvironment(image *Canvas) *EquirectangularEnvironmentT {
+	return &EquirectangularEnvironmentT{Image: image, Intensity: 1}
+}
+
+// EquirectangularEnvironmentT is a latitude-longitude image environment.
+type EquirectangularEnvironmentT struct {
+	Image *Canvas
+	// Intensity scales the colors of the image.
+	Intensity float64
+}
+
+var _ Environment = &EquirectangularEnvironmentT{}
+
+// ColorAt returns the color of the image in the provided direction.
+func (e *EquirectangularEnvironmentT) ColorAt(direction Tuple) Tuple {
+	u, v := equirectangularUV(direction)
+	return e.Image.sample(u*float64(e.Image.width), v*float64(e.Image.height), true).MultScalar(e.Intensity)
+}
+
+// equirectangularUV returns the image coordinates (each in [0,1]) of the
+// direction in a latitude-longitude image. It is the inverse of the
+// equirectangular camera projection.
+func equirectangularUV(direction Tuple) (float64, float64) {
+	longitude := math.Atan2(-direction.X(), -direction.Z())
+	latitude := math.Asin(math.Max(-1, math.Min(1, direction.Y())))
+	return longitude/(2*math.Pi) + 0.5, 0.5 - latitude/math.Pi
+}
+
+// CubeMapEnvironment returns an environment from the six square faces of
+// a cube map in the order +X, -X, +Y, -Y, +Z, -Z, laid out following the
+// OpenGL cube map convention.
+func CubeMapEnvironment(faces [6]*Canvas) *CubeMapEnvironmentT {
+	for i, face := range faces {
+		if face == nil {
+			log.Fatalf("programming error - cube map face %v is missing", i)
+		}
+	}
+	return &CubeMapEnvironmentT{Faces: faces, Intensity: 1}
+}
+
+// CubeMapEnvironmentT is a cube map image environment.
+type CubeMapEnvironmentT struct {
+	Faces [6]*Canvas
+	// Intensity scales the colors of the images.
+	Intensity float64
+}
+
+var _ Environment = &CubeMapEnvironmentT{}
+
+// ColorAt returns the color of the cube map in the provided direction.
+func (e *CubeMapEnvironmentT) ColorAt(direction Tuple) Tuple {
+	x, y, z := direction.X(), direction.Y(), direction.Z()
+	ax, ay, az := math.Abs(x), math.Abs(y), math.Abs(z)
+
+	var face int
+	var sc, tc, ma float64
+	switch {
+	case ax >= ay && ax >= az && x > 0:
+		face, sc, tc, ma = 0, -z, -y, ax
+	case ax >= ay && ax >= az:
+		face, sc, tc, ma = 1, z, -y, ax
+	case ay >= az && y > 0:
+		face, sc, tc, ma = 2, x, z, ay
+	case ay >= az:
+		face, sc, tc, ma = 3, x, -z, ay
+	case z > 0:
+		face, sc, tc, ma = 4, x, -y, az
+	default:
+		face, sc, tc, ma = 5, -x, -y, az
+	}
+
+	img := e.Faces[face]
+	u := (sc/ma + 1) / 2
+	v := (tc/ma + 1) / 2
+	return img.sample(u*float64(img.width), v*float64(img.height), false).MultScalar(e.Intensity)
+}
+
+// sample returns the bilinearly filtered color of the canvas at the
+// provided continuous pixel coordinates, where pixel (i,j) covers
+// [i,i+1)x[j,j+1). The X coordinate wraps around if wrapX is true and is
+// clamped to the canvas otherwise; the Y coordinate is always clamped.
+func (c *Canvas) sample(x, y float64, wrapX bool) Tuple {
+	fx, fy := x-0.5, y-0.5
+	x0, y0 := int(math.Floor(fx)), int(math.Floor(fy))
+	tx, ty := fx-float64(x0), fy-float64(y0)
+
+	px := func(i int) int {
+		if wrapX {
+			return ((i % c.width) + c.width) % c.width
+		}
+		return minInt(maxInt(i, 0), c.width-1)
+	}
+	py := func(j int) int {
+		return minInt(maxInt(j, 0), c.height-1)
+	}
+
+	c00 := c.PixelAt(px(x0), py(y0))
+	c10 := c.PixelAt(px(x0+1), py(y0))
+	c01 := c.PixelAt(px(x0), py(y0+1))
+	c11 := c.PixelAt(px(x0+1), py(y0+1))
+
+	top := c00.MultScalar(1 - tx).Add(c10.MultScalar(tx))
+	bottom := c01.MultScalar(1 - tx).Add(c11.MultScalar(tx))
+	return top.MultScalar(1 - ty).Add(bottom.MultScalar(ty))
+}
@@ -0,0 +1,147 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWorldT_ColorAt_Environment(t *testing.T) {
+	sky := Color(0.2, 0.4, 0.6)
+
+	mirror := Plane()
+	mirror.SetTransform(Translation(0, -1, 0))
+	m := GetMaterial()
+	m.Ambient, m.Diffuse, m.Specular, m.Reflective = 0, 0, 0, 1
+	mirror.SetMaterial(m)
+
+	tests := []struct {
+		name        string
+		environment Environment
+		objects     []Object
+		ray         RayT
+		want        Tuple
+	}{
+		{
+			name: "no environment",
+			ray:  Ray(Point(0, 0, 0), Vector(0, 0, 1)),
+			want: Color(0, 0, 0),
+		},
+		{
+			name:        "solid",
+			environment: SolidEnvironment(sky),
+			ray:         Ray(Point(0, 0, 0), Vector(0, 0, 1)),
+			want:        sky,
+		},
+		{
+			name:        "gradient up",
+			environment: GradientEnvironment(Color(1, 1, 1), sky),
+			ray:         Ray(Point(0, 0, 0), Vector(0, 2, 0)),
+			want:        sky,
+		},
+		{
+			name:        "gradient at the horizon",
+			environment: GradientEnvironment(Color(1, 1, 1), sky),
+			ray:         Ray(Point(0, 0, 0), Vector(1, 0, 0)),
+			want:        Color(0.6, 0.7, 0.8),
+		},
+		{
+			name:        "reflected in a mirror",
+			environment: SolidEnvironment(sky),
+			objects:     []Object{mirror},
+			ray:         Ray(Point(0, 0, -1), Vector(0, -1, 1).Normalize()),
+			want:        sky,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := World()
+			w.Environment = tt.environment
+			w.Objects = tt.objects
+			if got := w.ColorAt(tt.ray, 4); !got.Equal(tt.want) {
+				t.Errorf("ColorAt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkyEnvironmentT_ColorAt(t *testing.T) {
+	e := SkyEnvironment(Vector(1, 0.3, 0), 2.5)
+
+	if got := e.ColorAt(Vector(0, -1, 0)); !got.Equal(e.GroundColor) {
+		t.Errorf("below the horizon = %v, want %v", got, e.GroundColor)
+	}
+	if got := e.ColorAt(e.SunDirection); !got.Equal(e.SunColor) {
+		t.Errorf("toward the sun = %v, want %v", got, e.SunColor)
+	}
+
+	zenith := e.ColorAt(Vector(0, 1, 0))
+	luminance := 0.2126*zenith.Red() + 0.7152*zenith.Green() + 0.0722*zenith.Blue()
+	if math.Abs(luminance-e.Brightness) > 1e-2 {
+		t.Errorf("zenith luminance = %v, want %v", luminance, e.Brightness)
+	}
+	if zenith.Blue() <= zenith.Red() {
+		t.Errorf("zenith = %v, want a blue sky", zenith)
+	}
+
+	nearSun := e.ColorAt(Vector(1, 0.4, 0.1).Normalize())
+	awayFromSun := e.ColorAt(Vector(-1, 0.4, 0.1).Normalize())
+	if nearSun.Green() <= awayFromSun.Green() {
+		t.Errorf("sky near the sun = %v, want brighter than away from it %v", nearSun, awayFromSun)
+	}
+}
+
+func TestEquirectangularEnvironmentT_ColorAt(t *testing.T) {
+	img := NewCanvas(4, 2)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			img.WritePixel(x, y, Color(float64(x)/4, float64(y)/2, 1))
+		}
+	}
+	e := EquirectangularEnvironment(img)
+	e.Intensity = 2
+
+	// Rays of an equirectangular camera look up their own pixels.
+	c := Camera(4, 2, math.Pi/2)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			want := img.PixelAt(x, y).MultScalar(2)
+			if got := e.ColorAt(c.equirectangularDirection(x, y)); !got.Equal(want) {
+				t.Errorf("ColorAt pixel (%v,%v) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestCubeMapEnvironmentT_ColorAt(t *testing.T) {
+	var faces [6]*Canvas
+	for i := range faces {
+		faces[i] = NewCanvas(2, 2)
+		for y := 0; y < 2; y++ {
+			for x := 0; x < 2; x++ {
+				faces[i].WritePixel(x, y, Color(float64(i), float64(x), float64(y)))
+			}
+		}
+	}
+	e := CubeMapEnvironment(faces)
+
+	tests := []struct {
+		direction Tuple
+		want      Tuple
+	}{
+		{direction: Vector(1, 0, 0), want: Color(0, 0.5, 0.5)},
+		{direction: Vector(-1, 0, 0), want: Color(1, 0.5, 0.5)},
+		{direction: Vector(0, 1, 0), want: Color(2, 0.5, 0.5)},
+		{direction: Vector(0, -1, 0), want: Color(3, 0.5, 0.5)},
+		{direction: Vector(0, 0, 1), want: Color(4, 0.5, 0.5)},
+		{direction: Vector(0, 0, -1), want: Color(5, 0.5, 0.5)},
+		{direction: Vector(-0.5, 0.5, 1).Normalize(), want: Color(4, 0, 0)},
+		{direction: Vector(0.5, -0.5, 1).Normalize(), want: Color(4, 1, 1)},
+	}
+
+	for _, tt := range tests {
+		if got := e.ColorAt(tt.direction); !got.Equal(tt.want) {
+			t.Errorf("ColorAt(%v) = %v, want %v", tt.direction, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package rtc
+
+import (
+	"log"
+	"math"
+)
+
+// Extrusion creates a prism by sweeping the closed polygon along the Y axis
+// from Minimum (0) to Maximum (1). The polygon points lie in the X-Z plane
+// (their Y is ignored) and may be listed in either winding order; the
+// polygon may be concave but must not intersect itself. The ends of the
+// extrusion are capped with the polygon unless Closed is false.
+// The Face of each intersection is the index of the wall (the edge from
+// point Face to the next point), or len(Polygon) for the bottom cap and
+// len(Polygon)+1 for the top cap.
+// It implements the Object interface.
+func Extrusion(polygon []Tuple) *ExtrusionT {
+	if len(polygon) < 3 {
+		log.Fatalf("programming error - extrusion needs at least 3 polygon points, got %v", len(polygon))
+	}
+
+	e := &ExtrusionT{
+		Shape:   Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Polygon: make([]Tuple, len(polygon)),
+		Minimum: 0,
+		Maximum: 1,
+		Closed:  true,
+	}
+
+	var area float64
+	for i, p := range polygon {
+		e.Polygon[i] = Point(p.X(), 0, p.Z())
+		q := polygon[(i+1)%len(polygon)]
+		area += p.X()*q.Z() - q.X()*p.Z()
+	}
+	e.orientation = 1
+	if area < 0 {
+		e.orientation = -1
+	}
+
+	return e
+}
+
+// ExtrusionT represents a polygonal prism.
+type ExtrusionT struct {
+	Shape
+	Polygon []Tuple
+	Minimum float64
+	Maximum float64
+	Closed  bool
+
+	// orientation is 1 for counterclockwise polygons (in X-Z) and -1 for
+	// clockwise ones, so that wall normals always point outward.
+	orientation float64
+}
+
+var _ Object = &ExtrusionT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (e *ExtrusionT) SetTransform(m M4) Object {
+	e.Transform = m
+	return e
+}
+
+// SetMaterial sets the object's material.
+func (e *ExtrusionT) SetMaterial(material MaterialT) Object {
+	e.setMaterial(material)
+	return e
+}
+
+// SetParent sets the object's parent object.
+func (e *ExtrusionT) SetParent(parent Object) Object {
+	e.Parent = parent
+	return e
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (e *ExtrusionT) Bounds() *BoundsT {
+	bounds := Bounds()
+	for _, p := range e.Polygon {
+		bounds.UpdateBounds(Point(p.X(), e.Minimum, p.Z()))
+		bounds.UpdateBounds(Point(p.X(), e.Maximum, p.Z()))
+	}
+	return bounds
+}
+
+// contains reports whether the (x,z) position lies inside the polygon
+// using the even-odd rule.
+func (e *ExtrusionT) contains(x, z float64) bool {
+	inside := false
+	for i, j := 0, len(e.Polygon)-1; i < len(e.Polygon); j, i = i, i+1 {
+		pi, pj := e.Polygon[i], e.Polygon[j]
+		if (pi.Z() > z) != (pj.Z() > z) &&
+			x < pi.X()+(z-pi.Z())*(pj.X()-pi.X())/(pj.Z()-pi.Z()) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func (e *ExtrusionT) intersectCaps(ray RayT, xs []IntersectionT) []IntersectionT {
+	if !e.Closed || math.Abs(ray.Direction.Y()) < epsilon {
+		return xs
+	}
+
+	t := (e.Minimum - ray.Origin.Y()) / ray.Direction.Y()
+	if p := ray.Position(t); e.contains(p.X(), p.Z()) {
+		xs = append(xs, IntersectionT{T: t, Object: e, Face: len(e.Polygon)})
+	}
+
+	t = (e.Maximum - ray.Origin.Y()) / ray.Direction.Y()
+	if p := ray.Position(t); e.contains(p.X(), p.Z()) {
+		xs = append(xs, IntersectionT{T: t, Object: e, Face: len(e.Polygon) + 1})
+	}
+
+	return xs
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+func (e *ExtrusionT) LocalIntersect(ray RayT) []IntersectionT {
+	ox, oz := ray.Origin.X(), ray.Origin.Z()
+	dx, dz := ray.Direction.X(), ray.Direction.Z()
+
+	var xs []IntersectionT
+	for i, p := range e.Polygon {
+		q := e.Polygon[(i+1)%len(e.Polygon)]
+		ex, ez := q.X()-p.X(), q.Z()-p.Z()
+
+		// Solve origin + t*direction = p + s*edge in the X-Z plane.
+		denom := dx*ez - dz*ex
+		if math.Abs(denom) < eqnEpsilon {
+			continue
+		}
+		px, pz := p.X()-ox, p.Z()-oz
+		t := (px*ez - pz*ex) / denom
+		s := (px*dz - pz*dx) / denom
+		// Each point belongs to the edge starting at it, so hits on shared
+		// points are not duplicated.
+		if s < 0 || s >= 1 {
+			continue
+		}
+		if y := ray.Origin.Y() + t*ray.Direction.Y(); e.Minimum < y && y < e.Maximum {
+			xs = append(xs, IntersectionT{T: t, Object: e, Face: i})
+		}
+	}
+
+	return Intersections(e.intersectCaps(ray, xs)...)
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+func (e *ExtrusionT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	switch hit.Face {
+	case len(e.Polygon):
+		return Vector(0, -1, 0)
+	case len(e.Polygon) + 1:
+		return Vector(0, 1, 0)
+	}
+
+	p, q := e.Polygon[hit.Face], e.Polygon[(hit.Face+1)%len(e.Polygon)]
+	return Vector(q.Z()-p.Z(), 0, p.X()-q.X()).MultScalar(e.orientation).Normalize()
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (e *ExtrusionT) Includes(other Object) bool {
+	return e == other
+}
@@ -0,0 +1,85 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExtrusionT_LocalIntersect(t *testing.T) {
+	// A concave L-shaped polygon, listed clockwise in X-Z.
+	polygon := []Tuple{
+		Point(0, 0, 2), Point(1, 0, 2), Point(1, 0, 1),
+		Point(2, 0, 1), Point(2, 0, 0), Point(0, 0, 0),
+	}
+
+	tests := []struct {
+		name        string
+		open        bool
+		ray         RayT
+		wantT       []float64
+		wantNormals []Tuple
+	}{
+		{
+			name:        "walls through the notch",
+			ray:         Ray(Point(-1, 0.5, 1.5), Vector(1, 0, 0)),
+			wantT:       []float64{1, 2},
+			wantNormals: []Tuple{Vector(-1, 0, 0), Vector(1, 0, 0)},
+		},
+		{
+			name:        "walls through the base",
+			ray:         Ray(Point(-1, 0.5, 0.5), Vector(1, 0, 0)),
+			wantT:       []float64{1, 3},
+			wantNormals: []Tuple{Vector(-1, 0, 0), Vector(1, 0, 0)},
+		},
+		{
+			name:        "caps",
+			ray:         Ray(Point(0.5, 5, 1.5), Vector(0, -1, 0)),
+			wantT:       []float64{4, 5},
+			wantNormals: []Tuple{Vector(0, 1, 0), Vector(0, -1, 0)},
+		},
+		{
+			name: "open caps",
+			open: true,
+			ray:  Ray(Point(0.5, 5, 1.5), Vector(0, -1, 0)),
+		},
+		{
+			name: "miss the notch",
+			ray:  Ray(Point(1.5, 5, 1.5), Vector(0, -1, 0)),
+		},
+		{
+			name: "miss above",
+			ray:  Ray(Point(-1, 1.5, 0.5), Vector(1, 0, 0)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Extrusion(polygon)
+			e.Closed = !tt.open
+			xs := e.LocalIntersect(tt.ray)
+			if len(xs) != len(tt.wantT) {
+				t.Fatalf("LocalIntersect = %v, want t = %v", xs, tt.wantT)
+			}
+			for i, want := range tt.wantT {
+				if math.Abs(xs[i].T-want) > epsilon {
+					t.Errorf("xs[%v].T = %v, want %v", i, xs[i].T, want)
+				}
+				if n := e.LocalNormalAt(tt.ray.Position(xs[i].T), &xs[i]); !n.Equal(tt.wantNormals[i]) {
+					t.Errorf("normal[%v] = %v, want %v", i, n, tt.wantNormals[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExtrusionT_Bounds(t *testing.T) {
+	e := Extrusion([]Tuple{Point(-1, 0, 0), Point(0, 0, 2), Point(3, 0, -1)})
+	e.Minimum, e.Maximum = -2, 4
+	b := e.Bounds()
+	if want := Point(-1, -2, -1); !b.Min.Equal(want) {
+		t.Errorf("Bounds().Min = %v, want %v", b.Min, want)
+	}
+	if want := Point(3, 4, 2); !b.Max.Equal(want) {
+		t.Errorf("Bounds().Max = %v, want %v", b.Max, want)
+	}
+}
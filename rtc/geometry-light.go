@@ -0,0 +1,192 @@
+package rtc
+
+import (
+	"log"
+	"math"
+	"math/rand"
+)
+
+// SurfaceSampler is implemented by objects whose surface can be sampled,
+// allowing them to be used as geometry lights.
+type SurfaceSampler interface {
+	// SampleSurface maps the provided (u,v) values (each in the range [0,1))
+	// to a point on the surface. It returns the point in the object space of
+	// the returned object, which is the object itself for primitive shapes
+	// or one of its descendants for groups.
+	SampleSurface(u, v float64) (Tuple, Object)
+	// SurfaceArea returns the area of the surface in object space.
+	SurfaceArea() float64
+}
+
+// GeometryLightT represents an emissive object that is sampled as a light
+// source for direct illumination and soft shadows.
+type GeometryLightT struct {
+	Object Object
+	USteps int
+	VSteps int
+	Jitter bool
+}
+
+// GeometryLight returns a geometry light for the provided emissive object
+// using usteps*vsteps samples per shading point. The object must implement
+// the SurfaceSampler interface, and its intensity is taken from the Emission
+// of its material (or the material of the sampled descendant for groups).
+func GeometryLight(object Object, usteps, vsteps int) *GeometryLightT {
+	if _, ok := object.(SurfaceSampler); !ok {
+		log.Fatalf("programming error - %T cannot be used as a geometry light", object)
+	}
+	if usteps < 1 || vsteps < 1 {
+		log.Fatalf("programming error - geometry light needs at least one sample, got %vx%v", usteps, vsteps)
+	}
+	return &GeometryLightT{
+		Object: object,
+		USteps: usteps,
+		VSteps: vsteps,
+		Jitter: true,
+	}
+}
+
+// geometryLightSample is a world-space point light sampled from the surface
+// of a geometry light.
+type geometryLightSample struct {
+	position  Tuple
+	intensity Tuple
+}
+
+// samples returns the stratified world-space samples of the light.
+// The intensity of each sample is scaled by the number of samples.
+func (g *GeometryLightT) samples() []geometryLightSample {
+	sampler := g.Object.(SurfaceSampler)
+	scale := 1 / float64(g.USteps*g.VSteps)
+
+	result := make([]geometryLightSample, 0, g.USteps*g.VSteps)
+	for j := 0; j < g.VSteps; j++ {
+		for i := 0; i < g.USteps; i++ {
+			du, dv := 0.5, 0.5
+			if g.Jitter {
+				du, dv = rand.Float64(), rand.Float64()
+			}
+			u := (float64(i) + du) / float64(g.USteps)
+			v := (float64(j) + dv) / float64(g.VSteps)
+
+			point, object := sampler.SampleSurface(u, v)
+			result = append(result, geometryLightSample{
+				position:  ObjectToWorld(object, point),
+				intensity: object.GetMaterial().Emission.MultScalar(scale),
+			})
+		}
+	}
+	return result
+}
+
+// SampleSurface maps the provided (u,v) values to a point on the sphere.
+func (s *SphereT) SampleSurface(u, v float64) (Tuple, Object) {
+	y := 1 - 2*u
+	r := math.Sqrt(math.Max(0, 1-y*y))
+	phi := 2 * math.Pi * v
+	return Point(r*math.Cos(phi), y, r*math.Sin(phi)), s
+}
+
+// SurfaceArea returns the area of the unit sphere.
+func (s *SphereT) SurfaceArea() float64 {
+	return 4 * math.Pi
+}
+
+// SampleSurface maps the provided (u,v) values to a point on the cube.
+// u selects the face as well as the position on that face.
+func (c *CubeT) SampleSurface(u, v float64) (Tuple, Object) {
+	u *= 6
+	face := math.Floor(u)
+	a, b := 2*(u-face)-1, 2*v-1
+	switch face {
+	case 0:
+		return Point(1, a, b), c
+	case 1:
+		return Point(-1, a, b), c
+	case 2:
+		return Point(a, 1, b), c
+	case 3:
+		return Point(a, -1, b), c
+	case 4:
+		return Point(a, b, 1), c
+	default:
+		return Point(a, b, -1), c
+	}
+}
+
+// SurfaceArea returns the area of the cube's six 2x2 faces.
+func (c *CubeT) SurfaceArea() float64 {
+	return 24
+}
+
+// SampleSurface maps the provided (u,v) values uniformly to a point on
+// the triangle.
+func (t *TriangleT) SampleSurface(u, v float64) (Tuple, Object) {
+	su := math.Sqrt(u)
+	return t.P1.Add(t.E1.MultScalar(su * (1 - v))).Add(t.E2.MultScalar(su * v)), t
+}
+
+// SurfaceArea returns the area of the triangle.
+func (t *TriangleT) SurfaceArea() float64 {
+	return t.E1.Cross(t.E2).Magnitude() / 2
+}
+
+// SampleSurface maps the provided (u,v) values uniformly to a point on
+// the smooth triangle.
+func (s *SmoothTriangleT) SampleSurface(u, v float64) (Tuple, Object) {
+	p, _ := s.TriangleT.SampleSurface(u, v)
+	return p, s
+}
+
+// SampleSurface maps the provided (u,v) values to a point on one of the
+// group's children. u selects the child, in proportion to its surface
+// area, as well as the position on it.
+func (g *GroupT) SampleSurface(u, v float64) (Tuple, Object) {
+	samplers, areas := g.surfaceSamplers()
+	if len(samplers) == 0 {
+		log.Fatalf("programming error - group has no children that can be sampled")
+	}
+
+	cdf, total := cumulative(areas)
+	if total <= 0 {
+		// Degenerate children are sampled uniformly instead.
+		for i := range areas {
+			areas[i] = 1
+		}
+		cdf, _ = cumulative(areas)
+	}
+	index, u, _ := sampleCumulative(cdf, u)
+	return samplers[index].SampleSurface(u, v)
+}
+
+// SurfaceArea returns the total area of the group's children that can be
+// sampled, in the group's object space.
+func (g *GroupT) SurfaceArea() float64 {
+	_, areas := g.surfaceSamplers()
+	var total float64
+	for _, area := range areas {
+		total += area
+	}
+	return total
+}
+
+// surfaceSamplers returns the group's children that can be sampled along
+// with their areas in the group's object space.
+func (g *GroupT) surfaceSamplers() ([]SurfaceSampler, []float64) {
+	var samplers []SurfaceSampler
+	var areas []float64
+	for _, child := range g.Children {
+		if s, ok := child.(SurfaceSampler); ok {
+			samplers = append(samplers, s)
+			areas = append(areas, s.SurfaceArea()*areaScale(child.GetTransform()))
+		}
+	}
+	return samplers, areas
+}
+
+// areaScale returns the factor by which the transform scales areas. It is
+// exact for rotations, translations and uniform scaling, and uses the
+// mean scale for non-uniform scaling.
+func areaScale(transform M4) float64 {
+	return math.Pow(math.Abs(transform.Determinant()), 2.0/3)
+}
@@ -0,0 +1,214 @@
+package rtc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSurfaceSampler_SampleSurface(t *testing.T) {
+	tri := Triangle(Point(0, 1, 0), Point(-1, 0, 0), Point(1, 0, 0))
+
+	tests := []struct {
+		name   string
+		object SurfaceSampler
+		u      float64
+		v      float64
+		want   Tuple
+	}{
+		{name: "sphere top", object: Sphere(), u: 0, v: 0, want: Point(0, 1, 0)},
+		{name: "sphere equator", object: Sphere(), u: 0.5, v: 0.25, want: Point(0, 0, 1)},
+		{name: "sphere bottom", object: Sphere(), u: 1, v: 0.5, want: Point(0, -1, 0)},
+		{name: "cube +x face", object: Cube(), u: 0.5 / 6, v: 0.5, want: Point(1, 0, 0)},
+		{name: "cube -y face", object: Cube(), u: 3.5 / 6, v: 0.5, want: Point(0, -1, 0)},
+		{name: "cube -z face corner", object: Cube(), u: 5 / 6.0, v: 0, want: Point(-1, -1, -1)},
+		{name: "triangle first vertex", object: tri, u: 0, v: 0, want: Point(0, 1, 0)},
+		{name: "triangle second vertex", object: tri, u: 1, v: 0, want: Point(-1, 0, 0)},
+		{name: "triangle third vertex", object: tri, u: 1, v: 1, want: Point(1, 0, 0)},
+		{name: "triangle interior", object: tri, u: 0.25, v: 0.5, want: Point(0, 0.5, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, object := tt.object.SampleSurface(tt.u, tt.v)
+			if !got.Equal(tt.want) {
+				t.Errorf("SampleSurface(%v,%v) = %v, want %v", tt.u, tt.v, got, tt.want)
+			}
+			if object != tt.object.(Object) {
+				t.Errorf("SampleSurface object = %v, want %v", object, tt.object)
+			}
+		})
+	}
+}
+
+func TestGroupT_SampleSurface(t *testing.T) {
+	s1 := Sphere()
+	s2 := Sphere()
+	s2.SetTransform(Translation(5, 0, 0))
+	g := Group(s1, Plane(), s2)
+	g.SetTransform(Scaling(2, 2, 2))
+
+	tests := []struct {
+		u          float64
+		wantObject Object
+		wantWorld  Tuple
+	}{
+		{u: 0, wantObject: s1, wantWorld: Point(0, 2, 0)},
+		{u: 0.5, wantObject: s2, wantWorld: Point(10, 2, 0)},
+		{u: 0.75, wantObject: s2, wantWorld: Point(12, 0, 0)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test #%v", i), func(t *testing.T) {
+			p, object := g.SampleSurface(tt.u, 0)
+			if object != tt.wantObject {
+				t.Fatalf("SampleSurface object = %v, want %v", object, tt.wantObject)
+			}
+			if got := ObjectToWorld(object, p); !got.Equal(tt.wantWorld) {
+				t.Errorf("ObjectToWorld(SampleSurface) = %v, want %v", got, tt.wantWorld)
+			}
+		})
+	}
+}
+
+func TestSurfaceSampler_SurfaceArea(t *testing.T) {
+	small := Sphere()
+	big := Sphere()
+	big.SetTransform(Translation(10, 0, 0).Mult(Scaling(3, 3, 3)))
+
+	tests := []struct {
+		name   string
+		object SurfaceSampler
+		want   float64
+	}{
+		{name: "sphere", object: Sphere(), want: 4 * math.Pi},
+		{name: "cube", object: Cube(), want: 24},
+		{name: "triangle", object: Triangle(Point(0, 1, 0), Point(-1, 0, 0), Point(1, 0, 0)), want: 1},
+		{name: "disk", object: Disk(), want: math.Pi},
+		{name: "annulus", object: Annulus(), want: 0.75 * math.Pi},
+		{name: "quad", object: Quad(Point(0, 0, 0), Vector(2, 0, 0), Vector(0, 0, 3)), want: 6},
+		{name: "group of scaled children", object: Group(small, Plane(), big), want: 40 * math.Pi},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.object.SurfaceArea(); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("SurfaceArea = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupT_SampleSurface_WeightsChildrenByArea(t *testing.T) {
+	small := Sphere()
+	big := Sphere()
+	big.SetTransform(Translation(10, 0, 0).Mult(Scaling(3, 3, 3)))
+	g := Group(small, big)
+
+	// The big sphere has 9 times the area of the small one, so it gets
+	// 90% of the samples.
+	tests := []struct {
+		u          float64
+		wantObject Object
+		wantWorld  Tuple
+	}{
+		{u: 0, wantObject: small, wantWorld: Point(0, 1, 0)},
+		{u: 0.05, wantObject: small, wantWorld: Point(1, 0, 0)},
+		{u: 0.1, wantObject: big, wantWorld: Point(10, 3, 0)},
+		{u: 0.55, wantObject: big, wantWorld: Point(13, 0, 0)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test #%v", i), func(t *testing.T) {
+			p, object := g.SampleSurface(tt.u, 0)
+			if object != tt.wantObject {
+				t.Fatalf("SampleSurface object = %v, want %v", object, tt.wantObject)
+			}
+			if got := ObjectToWorld(object, p); !got.Equal(tt.wantWorld) {
+				t.Errorf("ObjectToWorld(SampleSurface) = %v, want %v", got, tt.wantWorld)
+			}
+		})
+	}
+}
+
+func TestGeometryLightT_Samples(t *testing.T) {
+	s := Sphere()
+	s.SetTransform(Translation(0, 5, 0))
+	s.GetMaterial().Emission = Color(2, 4, 8)
+
+	light := GeometryLight(s, 2, 4)
+	light.Jitter = false
+
+	samples := light.samples()
+	if got, want := len(samples), 8; got != want {
+		t.Fatalf("len(samples) = %v, want %v", got, want)
+	}
+
+	for i, sample := range samples {
+		if got, want := sample.intensity, Color(0.25, 0.5, 1); !got.Equal(want) {
+			t.Errorf("samples[%v].intensity = %v, want %v", i, got, want)
+		}
+		if got, want := sample.position.Sub(Point(0, 5, 0)).Magnitude(), 1.0; math.Abs(got-want) > epsilon {
+			t.Errorf("samples[%v] distance from center = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestWorldT_ShadeHit_WithEmission(t *testing.T) {
+	w := World()
+	s := Sphere()
+	s.GetMaterial().Emission = Color(0.5, 0.25, 0)
+	w.Objects = []Object{s}
+
+	r := Ray(Point(0, 0, -5), Vector(0, 0, 1))
+	xs := Intersections(Intersection(4, s), Intersection(6, s))
+	comps := xs[0].PrepareComputations(r, xs)
+
+	if got, want := w.ShadeHit(comps, maxReflections), Color(0.5, 0.25, 0); !got.Equal(want) {
+		t.Errorf("ShadeHit = %v, want %v", got, want)
+	}
+}
+
+func TestWorldT_GeometryLighting(t *testing.T) {
+	emitter := Triangle(Point(-1, 0, -1), Point(1, 0, -1), Point(0, 0, 1))
+	emitter.SetTransform(Translation(0, 10, 0))
+	emitter.GetMaterial().Emission = Color(1, 1, 1)
+
+	floor := Plane()
+	floor.GetMaterial().Color = Color(1, 1, 1)
+	floor.GetMaterial().Specular = 0
+	floor.GetMaterial().Diffuse = 1
+
+	light := GeometryLight(emitter, 4, 4)
+	light.Jitter = false
+
+	w := World()
+	w.Objects = []Object{emitter, floor}
+	w.GeometryLights = []*GeometryLightT{light}
+
+	r := Ray(Point(0, 1, 0), Vector(0, -1, 0))
+	xs := Intersections(Intersection(1, floor))
+	comps := xs[0].PrepareComputations(r, xs)
+
+	unoccluded := w.GeometryLighting(comps, light)
+	if unoccluded.Red() < 0.95 || unoccluded.Red() > 1 {
+		t.Errorf("unoccluded GeometryLighting = %v, want nearly white", unoccluded)
+	}
+
+	// A blocker covering half of the emitter produces a soft (partial) shadow.
+	blocker := Cube()
+	blocker.SetTransform(Translation(-1, 5, 0))
+	w.Objects = append(w.Objects, blocker)
+
+	partial := w.GeometryLighting(comps, light)
+	if partial.Red() <= 0 || partial.Red() >= unoccluded.Red() {
+		t.Errorf("partially occluded GeometryLighting = %v, want between 0 and %v", partial, unoccluded)
+	}
+
+	// An emitter does not light itself.
+	xs = Intersections(Intersection(9, emitter))
+	comps = xs[0].PrepareComputations(r, xs)
+	if got, want := w.GeometryLighting(comps, light), Color(0, 0, 0); !got.Equal(want) {
+		t.Errorf("emitter GeometryLighting = %v, want %v", got, want)
+	}
+}
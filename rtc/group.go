@@ -40,7 +40,7 @@ func (g *GroupT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (g *GroupT) SetMaterial(material MaterialT) Object {
-	g.Material = material
+	g.setMaterial(material)
 	return g
 }
 
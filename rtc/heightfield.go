@@ -0,0 +1,254 @@
+package rtc
+
+import (
+	"image"
+	"log"
+	"math"
+)
+
+// Heightfield creates a terrain surface from a grid of heights where
+// heights[z][x] is the height of the vertex in row z and column x.
+// The grid spans the unit square from (0,0) to (1,1) in the X-Z plane of
+// object space with the heights along the Y axis, so it is typically
+// scaled to the desired size. Each grid cell is split into two triangles
+// and normals are interpolated smoothly across them.
+// It implements the Object interface.
+func Heightfield(heights [][]float64) *HeightfieldT {
+	rows := len(heights)
+	if rows < 2 {
+		log.Fatalf("programming error - heightfield needs at least 2 rows, got %v", rows)
+	}
+	cols := len(heights[0])
+	if cols < 2 {
+		log.Fatalf("programming error - heightfield needs at least 2 columns, got %v", cols)
+	}
+
+	h := &HeightfieldT{
+		Shape:   Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Heights: make([][]float64, rows),
+		cols:    cols,
+		rows:    rows,
+	}
+
+	minHeight, maxHeight := math.Inf(1), math.Inf(-1)
+	for z, row := range heights {
+		if len(row) != cols {
+			log.Fatalf("programming error - heightfield row %v has %v columns, want %v", z, len(row), cols)
+		}
+		h.Heights[z] = append([]float64(nil), row...)
+		for _, y := range row {
+			minHeight = math.Min(minHeight, y)
+			maxHeight = math.Max(maxHeight, y)
+		}
+	}
+
+	h.bounds = &BoundsT{
+		Min: Point(0, minHeight, 0),
+		Max: Point(1, maxHeight, 1),
+	}
+	h.computeNormals()
+
+	return h
+}
+
+// HeightfieldFromImage creates a heightfield from a grayscale image where
+// the luminance of each pixel (from 0 for black to 1 for white) is used as
+// the height of the corresponding vertex. Image rows map to the Z axis and
+// image columns map to the X axis.
+// It implements the Object interface.
+func HeightfieldFromImage(img image.Image) *HeightfieldT {
+	bounds := img.Bounds()
+	heights := make([][]float64, bounds.Dy())
+	for z := range heights {
+		heights[z] = make([]float64, bounds.Dx())
+		for x := range heights[z] {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+z).RGBA()
+			heights[z][x] = (0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)) / 65535
+		}
+	}
+	return Heightfield(heights)
+}
+
+// HeightfieldT represents a heightfield terrain.
+type HeightfieldT struct {
+	Shape
+	Heights [][]float64
+
+	cols    int
+	rows    int
+	normals [][]Tuple
+	bounds  *BoundsT
+}
+
+var _ Object = &HeightfieldT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (h *HeightfieldT) SetTransform(m M4) Object {
+	h.Transform = m
+	return h
+}
+
+// SetMaterial sets the object's material.
+func (h *HeightfieldT) SetMaterial(material MaterialT) Object {
+	h.setMaterial(material)
+	return h
+}
+
+// SetParent sets the object's parent object.
+func (h *HeightfieldT) SetParent(parent Object) Object {
+	h.Parent = parent
+	return h
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (h *HeightfieldT) Bounds() *BoundsT {
+	return h.bounds
+}
+
+// vertex returns the object space position of the grid vertex at column x
+// and row z.
+func (h *HeightfieldT) vertex(x, z int) Tuple {
+	return Point(float64(x)/float64(h.cols-1), h.Heights[z][x], float64(z)/float64(h.rows-1))
+}
+
+// computeNormals estimates the normal at each vertex of the grid using
+// central differences (or one-sided differences along the edges).
+func (h *HeightfieldT) computeNormals() {
+	dx, dz := 1/float64(h.cols-1), 1/float64(h.rows-1)
+	h.normals = make([][]Tuple, h.rows)
+	for z := 0; z < h.rows; z++ {
+		h.normals[z] = make([]Tuple, h.cols)
+		for x := 0; x < h.cols; x++ {
+			x0, x1 := maxInt(x-1, 0), minInt(x+1, h.cols-1)
+			z0, z1 := maxInt(z-1, 0), minInt(z+1, h.rows-1)
+			slopeX := (h.Heights[z][x1] - h.Heights[z][x0]) / (float64(x1-x0) * dx)
+			slopeZ := (h.Heights[z1][x] - h.Heights[z0][x]) / (float64(z1-z0) * dz)
+			h.normals[z][x] = Vector(-slopeX, 1, -slopeZ).Normalize()
+		}
+	}
+}
+
+// cellAt returns the cell containing the provided object space (x,z)
+// position along with the fractional position within that cell.
+func (h *HeightfieldT) cellAt(x, z float64) (int, int, float64, float64) {
+	gx := x * float64(h.cols-1)
+	gz := z * float64(h.rows-1)
+	cx := minInt(maxInt(int(math.Floor(gx)), 0), h.cols-2)
+	cz := minInt(maxInt(int(math.Floor(gz)), 0), h.rows-2)
+	return cx, cz, gx - float64(cx), gz - float64(cz)
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+// The ray is walked through the cells of the grid in order, only testing
+// the two triangles of each cell it passes over.
+func (h *HeightfieldT) LocalIntersect(ray RayT) []IntersectionT {
+	bxs := h.bounds.LocalIntersect(ray, h)
+	if len(bxs) == 0 {
+		return nil
+	}
+	tEnter, tExit := bxs[0].T, bxs[1].T
+
+	entry := ray.Position(tEnter)
+	cx, cz, _, _ := h.cellAt(entry.X(), entry.Z())
+
+	sizeX, sizeZ := 1/float64(h.cols-1), 1/float64(h.rows-1)
+	stepX, tMaxX, tDeltaX := gridStep(ray.Origin.X(), ray.Direction.X(), cx, sizeX)
+	stepZ, tMaxZ, tDeltaZ := gridStep(ray.Origin.Z(), ray.Direction.Z(), cz, sizeZ)
+
+	var xs []IntersectionT
+	lastT := math.Inf(-1)
+	for cx >= 0 && cx < h.cols-1 && cz >= 0 && cz < h.rows-1 {
+		v00, v10 := h.vertex(cx, cz), h.vertex(cx+1, cz)
+		v01, v11 := h.vertex(cx, cz+1), h.vertex(cx+1, cz+1)
+
+		var cellTs []float64
+		if t, _, _, ok := intersectTriangle(ray, v00, v10, v11); ok {
+			cellTs = append(cellTs, t)
+		}
+		if t, _, _, ok := intersectTriangle(ray, v00, v11, v01); ok {
+			cellTs = append(cellTs, t)
+		}
+		if len(cellTs) == 2 && cellTs[1] < cellTs[0] {
+			cellTs[0], cellTs[1] = cellTs[1], cellTs[0]
+		}
+
+		for _, t := range cellTs {
+			// A ray crossing a shared edge hits both adjacent triangles.
+			if t-lastT < epsilon {
+				continue
+			}
+			p := ray.Position(t)
+			xs = append(xs, IntersectionWithUV(t, h, p.X(), p.Z()))
+			lastT = t
+		}
+
+		if math.Min(tMaxX, tMaxZ) > tExit {
+			break
+		}
+		if tMaxX < tMaxZ {
+			cx += stepX
+			tMaxX += tDeltaX
+		} else {
+			cz += stepZ
+			tMaxZ += tDeltaZ
+		}
+	}
+
+	return xs
+}
+
+// gridStep returns the cell step direction, the ray parameter at which the
+// ray leaves the current cell, and the ray parameter needed to cross one
+// cell along a single axis of the grid.
+func gridStep(origin, direction float64, cell int, size float64) (int, float64, float64) {
+	switch {
+	case direction > 0:
+		return 1, (float64(cell+1)*size - origin) / direction, size / direction
+	case direction < 0:
+		return -1, (float64(cell)*size - origin) / direction, -size / direction
+	default:
+		return 0, math.Inf(1), math.Inf(1)
+	}
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+// The vertex normals of the triangle containing the point are interpolated
+// using its barycentric coordinates.
+func (h *HeightfieldT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	cx, cz, fx, fz := h.cellAt(objectPoint.X(), objectPoint.Z())
+	fx = math.Max(0, math.Min(1, fx))
+	fz = math.Max(0, math.Min(1, fz))
+
+	n00, n10 := h.normals[cz][cx], h.normals[cz][cx+1]
+	n01, n11 := h.normals[cz+1][cx], h.normals[cz+1][cx+1]
+
+	if fx >= fz {
+		// Triangle (v00, v10, v11)
+		return n00.MultScalar(1 - fx).Add(n10.MultScalar(fx - fz)).Add(n11.MultScalar(fz))
+	}
+	// Triangle (v00, v11, v01)
+	return n00.MultScalar(1 - fz).Add(n11.MultScalar(fx)).Add(n01.MultScalar(fz - fx))
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (h *HeightfieldT) Includes(other Object) bool {
+	return h == other
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,218 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeightfieldT_LocalIntersect(t *testing.T) {
+	flat := Heightfield([][]float64{
+		{0.5, 0.5},
+		{0.5, 0.5},
+	})
+	ridge := Heightfield([][]float64{
+		{0, 0, 0},
+		{0, 1, 0},
+		{0, 0, 0},
+	})
+
+	tests := []struct {
+		name  string
+		h     *HeightfieldT
+		ray   RayT
+		wantT []float64
+		wantU []float64
+		wantV []float64
+	}{
+		{
+			name:  "A ray striking a flat heightfield from above",
+			h:     flat,
+			ray:   Ray(Point(0.25, 2, 0.75), Vector(0, -1, 0)),
+			wantT: []float64{1.5},
+			wantU: []float64{0.25},
+			wantV: []float64{0.75},
+		},
+		{
+			name: "A ray passing over a flat heightfield",
+			h:    flat,
+			ray:  Ray(Point(-1, 1, 0.5), Vector(1, 0, 0)),
+		},
+		{
+			name: "A ray missing the heightfield",
+			h:    flat,
+			ray:  Ray(Point(2, 2, 0.5), Vector(0, -1, 0)),
+		},
+		{
+			name:  "A ray passing through a ridge",
+			h:     ridge,
+			ray:   Ray(Point(-1, 0.25, 0.4), Vector(1, 0, 0)),
+			wantT: []float64{1.125, 1.775},
+			wantU: []float64{0.125, 0.775},
+			wantV: []float64{0.4, 0.4},
+		},
+		{
+			name:  "A diagonal ray striking the ridge",
+			h:     ridge,
+			ray:   Ray(Point(0, 1, 0), Vector(1, 0, 1)),
+			wantT: []float64{0.5},
+			wantU: []float64{0.5},
+			wantV: []float64{0.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.h.LocalIntersect(tt.ray)
+			if len(got) != len(tt.wantT) {
+				t.Fatalf("LocalIntersect = %v, want %v intersections", got, len(tt.wantT))
+			}
+			for i := range got {
+				if got[i].Object != tt.h {
+					t.Errorf("LocalIntersect[%v].Object = %v, want %v", i, got[i].Object, tt.h)
+				}
+				if math.Abs(got[i].T-tt.wantT[i]) > epsilon {
+					t.Errorf("LocalIntersect[%v].T = %v, want %v", i, got[i].T, tt.wantT[i])
+				}
+				if math.Abs(got[i].U-tt.wantU[i]) > epsilon || math.Abs(got[i].V-tt.wantV[i]) > epsilon {
+					t.Errorf("LocalIntersect[%v] UV = (%v,%v), want (%v,%v)", i, got[i].U, got[i].V, tt.wantU[i], tt.wantV[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHeightfieldT_LocalIntersect_LargeGrid(t *testing.T) {
+	// A tilted plane (y = x) sampled on a fine grid.
+	const n = 50
+	heights := make([][]float64, n)
+	for z := range heights {
+		heights[z] = make([]float64, n)
+		for x := range heights[z] {
+			heights[z][x] = float64(x) / (n - 1)
+		}
+	}
+	h := Heightfield(heights)
+
+	ray := Ray(Point(-1, 0.3, 0.77), Vector(1, 0, 0.1))
+	xs := h.LocalIntersect(ray)
+	if len(xs) != 1 {
+		t.Fatalf("LocalIntersect = %v, want 1 intersection", xs)
+	}
+	if got, want := xs[0].T, 1.3; math.Abs(got-want) > epsilon {
+		t.Errorf("LocalIntersect[0].T = %v, want %v", got, want)
+	}
+}
+
+func TestHeightfieldT_LocalNormalAt(t *testing.T) {
+	flat := Heightfield([][]float64{
+		{0.5, 0.5},
+		{0.5, 0.5},
+	})
+	slope := Heightfield([][]float64{
+		{0, 1},
+		{0, 1},
+	})
+	ridge := Heightfield([][]float64{
+		{0, 0, 0},
+		{0, 1, 0},
+		{0, 0, 0},
+	})
+
+	tests := []struct {
+		name  string
+		h     *HeightfieldT
+		point Tuple
+		want  Tuple
+	}{
+		{
+			name:  "flat",
+			h:     flat,
+			point: Point(0.3, 0.5, 0.6),
+			want:  Vector(0, 1, 0),
+		},
+		{
+			name:  "slope",
+			h:     slope,
+			point: Point(0.3, 0.3, 0.6),
+			want:  Vector(-1, 1, 0).Normalize(),
+		},
+		{
+			name:  "top of ridge",
+			h:     ridge,
+			point: Point(0.5, 1, 0.5),
+			want:  Vector(0, 1, 0),
+		},
+		{
+			name:  "corner of ridge",
+			h:     ridge,
+			point: Point(0, 0, 0),
+			want:  Vector(0, 1, 0),
+		},
+		{
+			name:  "edge of ridge",
+			h:     ridge,
+			point: Point(0, 0, 0.5),
+			want:  Vector(-2, 1, 0).Normalize(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.h.LocalNormalAt(tt.point, nil).Normalize()
+			if !got.Equal(tt.want) {
+				t.Errorf("LocalNormalAt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeightfieldT_Bounds(t *testing.T) {
+	h := Heightfield([][]float64{
+		{0, 2, 0},
+		{-1, 0, 0},
+	})
+
+	got := h.Bounds()
+	if want := Point(0, -1, 0); !got.Min.Equal(want) {
+		t.Errorf("Bounds.Min = %v, want %v", got.Min, want)
+	}
+	if want := Point(1, 2, 1); !got.Max.Equal(want) {
+		t.Errorf("Bounds.Max = %v, want %v", got.Max, want)
+	}
+
+	h.SetTransform(Scaling(10, 1, 10))
+	g := Group(h)
+	if want := Point(10, 2, 10); !g.Bounds().Max.Equal(want) {
+		t.Errorf("Group.Bounds.Max = %v, want %v", g.Bounds().Max, want)
+	}
+
+	xs := Intersect(g, Ray(Point(5, 5, 5), Vector(0, -1, 0)))
+	if len(xs) != 1 {
+		t.Fatalf("Intersect = %v, want 1 intersection", xs)
+	}
+	if xs[0].Object != h {
+		t.Errorf("Intersect[0].Object = %v, want %v", xs[0].Object, h)
+	}
+}
+
+func TestHeightfieldFromImage(t *testing.T) {
+	c := NewCanvas(3, 2)
+	c.WritePixel(1, 0, Color(1, 1, 1))
+	c.WritePixel(2, 1, Color(0.5, 0.5, 0.5))
+
+	h := HeightfieldFromImage(c)
+	want := [][]float64{
+		{0, 1, 0},
+		{0, 0, 0.5},
+	}
+	if len(h.Heights) != len(want) {
+		t.Fatalf("len(Heights) = %v, want %v", len(h.Heights), len(want))
+	}
+	for z, row := range want {
+		for x, w := range row {
+			if got := h.Heights[z][x]; math.Abs(got-w) > epsilon {
+				t.Errorf("Heights[%v][%v] = %v, want %v", z, x, got, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package rtc
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ImageLightT represents a latitude-longitude (equirectangular) HDR image
+// that surrounds the world and lights it, such as a studio HDRI.
+// The image is laid out like EquirectangularEnvironment, which is
+// typically used with the same image so that reflections and refractions
+// match the lighting.
+//
+// The image is importance-sampled: directions are chosen in proportion
+// to the brightness of the image, so that small bright areas (lamps,
+// windows, the sun) are sampled far more often than dim ones.
+type ImageLightT struct {
+	Image *Canvas
+	// Intensity scales the colors of the image.
+	Intensity float64
+	USteps    int
+	VSteps    int
+	Jitter    bool
+
+	// marginal is the cumulative distribution of the image rows and
+	// conditional holds the cumulative distribution of the columns
+	// within each row. Each has one more entry than it has bins.
+	marginal    []float64
+	conditional [][]float64
+}
+
+// ImageLight returns an image light for the provided latitude-longitude
+// image using usteps*vsteps samples per shading point.
+func ImageLight(image *Canvas, usteps, vsteps int) *ImageLightT {
+	if image == nil || image.width < 1 || image.height < 1 {
+		log.Fatalf("programming error - image light needs an image")
+	}
+	if usteps < 1 || vsteps < 1 {
+		log.Fatalf("programming error - image light needs at least one sample, got %vx%v", usteps, vsteps)
+	}
+
+	l := &ImageLightT{
+		Image:     image,
+		Intensity: 1,
+		USteps:    usteps,
+		VSteps:    vsteps,
+		Jitter:    true,
+	}
+	l.computeDistributions()
+	return l
+}
+
+// luminance returns the relative luminance of the linear color.
+func luminance(c Tuple) float64 {
+	return 0.2126*c.Red() + 0.7152*c.Green() + 0.0722*c.Blue()
+}
+
+// computeDistributions computes the sampling distributions of the image.
+// Each pixel is weighted by its luminance and by the solid angle it
+// covers, which shrinks toward the poles.
+func (l *ImageLightT) computeDistributions() {
+	w, h := l.Image.width, l.Image.height
+	rows := make([]float64, h)
+	l.conditional = make([][]float64, h)
+	for y := 0; y < h; y++ {
+		sinTheta := math.Sin(math.Pi * (float64(y) + 0.5) / float64(h))
+		weights := make([]float64, w)
+		for x := 0; x < w; x++ {
+			weights[x] = math.Max(0, luminance(l.Image.PixelAt(x, y))) * sinTheta
+		}
+		l.conditional[y], rows[y] = cumulative(weights)
+	}
+	l.marginal, _ = cumulative(rows)
+}
+
+// cumulative returns the normalized cumulative distribution of the weights
+// along with their total.
+func cumulative(weights []float64) ([]float64, float64) {
+	cdf := make([]float64, len(weights)+1)
+	for i, w := range weights {
+		cdf[i+1] = cdf[i] + w
+	}
+	total := cdf[len(weights)]
+	if total > 0 {
+		for i := range cdf {
+			cdf[i] /= total
+		}
+	}
+	return cdf, total
+}
+
+// sampleCumulative maps u in [0,1) to a bin of the distribution. It returns
+// the bin, the position of u within the bin (in [0,1)) and the probability
+// density of the bin over [0,1).
+func sampleCumulative(cdf []float64, u float64) (int, float64, float64) {
+	n := len(cdf) - 1
+	// The first entry greater than u ends the bin, which skips empty bins.
+	i := sort.Search(len(cdf), func(i int) bool { return cdf[i] > u }) - 1
+	i = minInt(maxInt(i, 0), n-1)
+
+	width := cdf[i+1] - cdf[i]
+	if width <= 0 {
+		return i, 0.5, 0
+	}
+	return i, math.Min((u-cdf[i])/width, 1-eqnEpsilon), width * float64(n)
+}
+
+// imageLightSample is a direction sampled from an image light along with
+// the intensity of light arriving from it.
+type imageLightSample struct {
+	direction Tuple
+	intensity Tuple
+}
+
+// samples returns the stratified, importance-sampled world-space
+// directions of the light. The intensity of each sample is its radiance
+// divided by its probability density and the number of samples (and by π,
+// so that a uniform image of color c lights a diffuse surface like a
+// light of intensity c shining straight at it).
+func (l *ImageLightT) samples() []imageLightSample {
+	if l.marginal[len(l.marginal)-1] == 0 {
+		return nil // the image is black
+	}
+
+	w, h := float64(l.Image.width), float64(l.Image.height)
+	scale := l.Intensity / float64(l.USteps*l.VSteps) / math.Pi
+
+	result := make([]imageLightSample, 0, l.USteps*l.VSteps)
+	for j := 0; j < l.VSteps; j++ {
+		for i := 0; i < l.USteps; i++ {
+			du, dv := 0.5, 0.5
+			if l.Jitter {
+				du, dv = rand.Float64(), rand.Float64()
+			}
+
+			y, fy, rowPDF := sampleCumulative(l.marginal, (float64(j)+dv)/float64(l.VSteps))
+			x, fx, columnPDF := sampleCumulative(l.conditional[y], (float64(i)+du)/float64(l.USteps))
+
+			phi := 2 * math.Pi * ((float64(x)+fx)/w - 0.5)
+			lambda := math.Pi * (0.5 - (float64(y)+fy)/h)
+			sinPhi, cosPhi := math.Sincos(phi)
+			sinLambda, cosLambda := math.Sincos(lambda)
+			if cosLambda <= 0 {
+				continue
+			}
+
+			// Convert the density over the image to a density over solid angle.
+			pdf := rowPDF * columnPDF / (2 * math.Pi * math.Pi * cosLambda)
+			if pdf <= 0 {
+				continue
+			}
+
+			result = append(result, imageLightSample{
+				direction: Vector(-sinPhi*cosLambda, sinLambda, -cosPhi*cosLambda),
+				intensity: l.Image.PixelAt(x, y).MultScalar(scale / pdf),
+			})
+		}
+	}
+	return result
+}
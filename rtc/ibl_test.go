@@ -0,0 +1,144 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func uniformCanvas(width, height int, color Tuple) *Canvas {
+	c := NewCanvas(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c.WritePixel(x, y, color)
+		}
+	}
+	return c
+}
+
+func TestImageLightT_Samples(t *testing.T) {
+	// A dim image with one bright pixel just below the horizon toward -Z.
+	image := uniformCanvas(16, 8, Color(0.001, 0.001, 0.001))
+	image.WritePixel(8, 4, Color(1000, 1000, 1000))
+
+	light := ImageLight(image, 8, 8)
+	light.Jitter = false
+
+	samples := light.samples()
+	if got, want := len(samples), 64; got != want {
+		t.Fatalf("len(samples) = %v, want %v", got, want)
+	}
+
+	var bright int
+	for i, sample := range samples {
+		if got := sample.direction.Magnitude(); math.Abs(got-1) > epsilon {
+			t.Errorf("samples[%v].direction magnitude = %v, want 1", i, got)
+		}
+		u, v := equirectangularUV(sample.direction)
+		if int(u*16) == 8 && int(v*8) == 4 {
+			bright++
+			if sample.direction.Z() > -0.8 {
+				t.Errorf("samples[%v].direction = %v, want within the bright pixel toward -Z", i, sample.direction)
+			}
+		}
+	}
+	if bright < 60 {
+		t.Errorf("samples of the bright pixel = %v, want nearly all of them", bright)
+	}
+}
+
+func TestImageLightT_Samples_Black(t *testing.T) {
+	light := ImageLight(NewCanvas(4, 2), 2, 2)
+	if got := light.samples(); len(got) != 0 {
+		t.Errorf("samples = %v, want none", got)
+	}
+}
+
+func TestWorldT_ImageLighting(t *testing.T) {
+	floor := Plane()
+	floor.GetMaterial().Color = Color(1, 1, 1)
+	floor.GetMaterial().Diffuse = 0.9
+	floor.GetMaterial().Specular = 0
+
+	tests := []struct {
+		name     string
+		image    *Canvas
+		overhead bool
+		blocker  bool
+		min      float64
+		max      float64
+	}{
+		{
+			name:  "uniform white image",
+			image: uniformCanvas(32, 16, Color(1, 1, 1)),
+			min:   0.85,
+			max:   0.95,
+		},
+		{
+			name:  "uniform gray image",
+			image: uniformCanvas(32, 16, Color(0.5, 0.5, 0.5)),
+			min:   0.425,
+			max:   0.475,
+		},
+		{
+			name: "image lit only from below",
+			image: func() *Canvas {
+				c := NewCanvas(32, 16)
+				for x := 0; x < 32; x++ {
+					c.WritePixel(x, 15, Color(10, 10, 10))
+				}
+				return c
+			}(),
+			min: 0,
+			max: 0,
+		},
+		{
+			name:     "overhead light",
+			image:    uniformCanvas(32, 16, Color(0.001, 0.001, 0.001)),
+			overhead: true,
+			min:      0.5,
+			max:      math.Inf(1),
+		},
+		{
+			name:     "overhead light blocked by a sphere",
+			image:    uniformCanvas(32, 16, Color(0.001, 0.001, 0.001)),
+			overhead: true,
+			blocker:  true,
+			min:      0,
+			max:      0.01,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.overhead {
+				for x := 0; x < 32; x++ {
+					tt.image.WritePixel(x, 0, Color(100, 100, 100))
+				}
+			}
+
+			light := ImageLight(tt.image, 16, 16)
+			light.Jitter = false
+
+			w := World()
+			w.Objects = []Object{floor}
+			if tt.blocker {
+				blocker := Sphere()
+				blocker.SetTransform(Translation(0, 5, 0).Mult(Scaling(3, 3, 3)))
+				w.Objects = append(w.Objects, blocker)
+			}
+			w.ImageLights = []*ImageLightT{light}
+
+			r := Ray(Point(0, 1, -1), Vector(0, -1, 1).Normalize())
+			xs := Intersections(Intersection(math.Sqrt2, floor))
+			comps := xs[0].PrepareComputations(r, xs)
+
+			got := w.ImageLighting(comps, light)
+			if got.Red() < tt.min || got.Red() > tt.max {
+				t.Errorf("ImageLighting = %v, want red in [%v,%v]", got, tt.min, tt.max)
+			}
+			if shaded := w.ShadeHit(comps, maxReflections); !shaded.Equal(got) {
+				t.Errorf("ShadeHit = %v, want %v", shaded, got)
+			}
+		})
+	}
+}
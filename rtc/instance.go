@@ -0,0 +1,171 @@
+package rtc
+
+import "log"
+
+// Instance creates an instance of the provided (shared) geometry with its
+// own transform. The same geometry may be used by any number of instances
+// without being copied; it must not be added to groups or the world itself.
+// By default the geometry keeps its own materials; calling SetMaterial on
+// the instance overrides the material of all of its surfaces.
+// It implements the Object interface.
+func Instance(object Object) *InstanceT {
+	return &InstanceT{
+		Shape:  Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Object: object,
+	}
+}
+
+// InstanceT represents an instance of shared geometry.
+type InstanceT struct {
+	Shape
+	Object Object
+
+	// OverrideMaterial reports whether the material of the instance is
+	// used in place of the materials of the instanced geometry.
+	OverrideMaterial bool
+}
+
+var _ Object = &InstanceT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (i *InstanceT) SetTransform(m M4) Object {
+	i.Transform = m
+	return i
+}
+
+// SetMaterial sets the object's material, which overrides the materials of
+// the instanced geometry.
+func (i *InstanceT) SetMaterial(material MaterialT) Object {
+	i.setMaterial(material)
+	i.OverrideMaterial = true
+	return i
+}
+
+// SetParent sets the object's parent object.
+func (i *InstanceT) SetParent(parent Object) Object {
+	i.Parent = parent
+	return i
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (i *InstanceT) Bounds() *BoundsT {
+	return UpdateTransformedBounds(i.Object, nil)
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+// Each intersection refers to the hit object as seen through this
+// instance, so that its transforms and material resolve correctly.
+func (i *InstanceT) LocalIntersect(ray RayT) []IntersectionT {
+	xs := Intersect(i.Object, ray)
+	for j := range xs {
+		xs[j].Object = instancedObject{object: xs[j].Object, instance: i}
+	}
+	return xs
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+func (i *InstanceT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	log.Fatalf("programming error - instances are abstract and do not have normals")
+	return Tuple{}
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (i *InstanceT) Includes(other Object) bool {
+	if o, ok := other.(instancedObject); ok && o.instance == i {
+		return true
+	}
+	return i == other
+}
+
+// instancedObject is an object within shared geometry as seen through an
+// instance. Its parent chain leads from the object up to the root of the
+// shared geometry and then continues through the instance.
+// It is a comparable value so that intersections with the same object
+// through the same instance are equal.
+type instancedObject struct {
+	object   Object
+	instance *InstanceT
+}
+
+var _ Object = instancedObject{}
+
+// unwrapInstanced returns the shared object that an object seen through
+// an instance wraps, or the object itself.
+func unwrapInstanced(object Object) Object {
+	if o, ok := object.(instancedObject); ok {
+		return o.object
+	}
+	return object
+}
+
+// GetParent returns the object's parent as seen through the instance.
+func (o instancedObject) GetParent() Object {
+	if o.object == o.instance.Object {
+		return o.instance
+	}
+	return instancedObject{object: o.object.GetParent(), instance: o.instance}
+}
+
+// GetTransform returns the object's transform 4x4 matrix.
+func (o instancedObject) GetTransform() M4 {
+	return o.object.GetTransform()
+}
+
+// GetMaterial returns the material of the instance if it overrides the
+// materials of its geometry, or the object's own material otherwise.
+// Objects that inherit their material look it up through the instance.
+func (o instancedObject) GetMaterial() *MaterialT {
+	if o.instance.OverrideMaterial {
+		return o.instance.GetMaterial()
+	}
+	if inheritsMaterial(o.object) {
+		return o.GetParent().GetMaterial()
+	}
+	return o.object.GetMaterial()
+}
+
+// SetTransform is not supported since the geometry is shared.
+func (o instancedObject) SetTransform(m M4) Object {
+	log.Fatalf("programming error - shared geometry cannot be modified through an instance")
+	return o
+}
+
+// SetMaterial is not supported since the geometry is shared.
+func (o instancedObject) SetMaterial(material MaterialT) Object {
+	log.Fatalf("programming error - shared geometry cannot be modified through an instance")
+	return o
+}
+
+// SetParent is not supported since the geometry is shared.
+func (o instancedObject) SetParent(parent Object) Object {
+	log.Fatalf("programming error - shared geometry cannot be modified through an instance")
+	return o
+}
+
+// Bounds returns the bounds of the object.
+func (o instancedObject) Bounds() *BoundsT {
+	return o.object.Bounds()
+}
+
+// LocalIntersect returns the intersections with the object.
+func (o instancedObject) LocalIntersect(ray RayT) []IntersectionT {
+	return o.object.LocalIntersect(ray)
+}
+
+// LocalNormalAt returns the normal vector of the object.
+func (o instancedObject) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	return o.object.LocalNormalAt(objectPoint, hit)
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (o instancedObject) Includes(other Object) bool {
+	if p, ok := other.(instancedObject); ok && p.instance == o.instance {
+		return o.object.Includes(p.object)
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInstanceT_Intersect(t *testing.T) {
+	// Shared geometry: a group holding a scaled sphere.
+	sphere := Sphere()
+	sphere.SetTransform(Scaling(2, 2, 2))
+	sphere.GetMaterial().Color = Color(1, 0, 0)
+	shared := Group(sphere)
+
+	left := Instance(shared)
+	left.SetTransform(Translation(-5, 0, 0))
+	right := Instance(shared)
+	right.SetTransform(Translation(5, 0, 0).Mult(Scaling(0.5, 0.5, 0.5)))
+	right.SetMaterial(GetMaterial())
+	right.GetMaterial().Color = Color(0, 0, 1)
+
+	w := World()
+	w.Objects = []Object{Group(left, right)}
+
+	tests := []struct {
+		name       string
+		ray        RayT
+		wantT      []float64
+		wantNormal Tuple
+		wantColor  Tuple
+	}{
+		{
+			name:       "left instance",
+			ray:        Ray(Point(-5, 0, -10), Vector(0, 0, 1)),
+			wantT:      []float64{8, 12},
+			wantNormal: Vector(0, 0, -1),
+			wantColor:  Color(1, 0, 0),
+		},
+		{
+			name:       "right instance with material override",
+			ray:        Ray(Point(5, 0, -10), Vector(0, 0, 1)),
+			wantT:      []float64{9, 11},
+			wantNormal: Vector(0, 0, -1),
+			wantColor:  Color(0, 0, 1),
+		},
+		{
+			name:       "hit from above on the left instance",
+			ray:        Ray(Point(-5, 10, 0), Vector(0, -1, 0)),
+			wantT:      []float64{8, 12},
+			wantNormal: Vector(0, 1, 0),
+			wantColor:  Color(1, 0, 0),
+		},
+		{
+			name: "between the instances",
+			ray:  Ray(Point(0, 0, -10), Vector(0, 0, 1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xs := w.IntersectWorld(tt.ray)
+			if len(xs) != len(tt.wantT) {
+				t.Fatalf("Intersect = %v, want %v intersections", xs, len(tt.wantT))
+			}
+			if len(xs) == 0 {
+				return
+			}
+			for i, want := range tt.wantT {
+				if math.Abs(xs[i].T-want) > epsilon {
+					t.Errorf("xs[%v].T = %v, want %v", i, xs[i].T, want)
+				}
+			}
+			if xs[0].Object != xs[1].Object {
+				t.Errorf("xs[0].Object = %v, want equal to xs[1].Object = %v", xs[0].Object, xs[1].Object)
+			}
+
+			comps := xs[0].PrepareComputations(tt.ray, xs)
+			if !comps.NormalVector.Equal(tt.wantNormal) {
+				t.Errorf("NormalVector = %v, want %v", comps.NormalVector, tt.wantNormal)
+			}
+			if got := comps.Object.GetMaterial().Color; !got.Equal(tt.wantColor) {
+				t.Errorf("material color = %v, want %v", got, tt.wantColor)
+			}
+		})
+	}
+
+	if sphere.GetParent() != shared {
+		t.Errorf("sphere.GetParent() = %v, want %v", sphere.GetParent(), shared)
+	}
+}
+
+func TestInstanceT_WorldToObject(t *testing.T) {
+	sphere := Sphere()
+	sphere.SetTransform(Translation(5, 0, 0))
+	shared := Group(sphere)
+	shared.SetTransform(Scaling(2, 2, 2))
+
+	instance := Instance(shared)
+	instance.SetTransform(RotationY(math.Pi / 2))
+	g := Group(instance)
+
+	xs := Intersect(g, Ray(Point(0, 0, -20), Vector(0, 0, 1)))
+	if len(xs) != 2 {
+		t.Fatalf("Intersect = %v, want 2 intersections", xs)
+	}
+
+	o := xs[0].Object
+	if got, want := WorldToObject(o, Point(0, 0, -10)), Point(0, 0, 0); !got.Equal(want) {
+		t.Errorf("WorldToObject = %v, want %v", got, want)
+	}
+	if got, want := ObjectToWorld(o, Point(-1, 0, 0)), Point(0, 0, -8); !got.Equal(want) {
+		t.Errorf("ObjectToWorld = %v, want %v", got, want)
+	}
+	if got, want := NormalToWorld(o, Vector(-1, 0, 0)), Vector(0, 0, 1); !got.Equal(want) {
+		t.Errorf("NormalToWorld = %v, want %v", got, want)
+	}
+	if got, want := xs[0].T, 8.0; math.Abs(got-want) > epsilon {
+		t.Errorf("xs[0].T = %v, want %v", got, want)
+	}
+}
+
+func TestInstanceT_BoundsAndIncludes(t *testing.T) {
+	sphere := Sphere()
+	sphere.SetTransform(Translation(1, 0, 0))
+	instance := Instance(sphere)
+	instance.SetTransform(Translation(0, 10, 0))
+
+	b := instance.Bounds()
+	if want := Point(0, -1, -1); !b.Min.Equal(want) {
+		t.Errorf("Bounds.Min = %v, want %v", b.Min, want)
+	}
+	if want := Point(2, 1, 1); !b.Max.Equal(want) {
+		t.Errorf("Bounds.Max = %v, want %v", b.Max, want)
+	}
+
+	g := Group(instance)
+	if want := Point(2, 11, 1); !g.Bounds().Max.Equal(want) {
+		t.Errorf("Group.Bounds.Max = %v, want %v", g.Bounds().Max, want)
+	}
+
+	other := Instance(sphere)
+	xs := Intersect(instance, Ray(Point(1, 10, -5), Vector(0, 0, 1)))
+	if len(xs) != 2 {
+		t.Fatalf("Intersect = %v, want 2 intersections", xs)
+	}
+	if !instance.Includes(xs[0].Object) {
+		t.Errorf("instance.Includes(hit) = false, want true")
+	}
+	if other.Includes(xs[0].Object) {
+		t.Errorf("other.Includes(hit) = true, want false")
+	}
+	if instance.Includes(sphere) {
+		t.Errorf("instance.Includes(sphere) = true, want false")
+	}
+}
+
+func TestInstanceT_InheritMaterial(t *testing.T) {
+	sphere := Sphere()
+	InheritMaterial(sphere)
+
+	instance := Instance(sphere)
+	InheritMaterial(instance)
+	g := Group(instance)
+	g.GetMaterial().Color = Color(0, 0, 1)
+
+	xs := g.LocalIntersect(Ray(Point(0, 0, -5), Vector(0, 0, 1)))
+	if len(xs) != 2 {
+		t.Fatalf("len(xs) = %v, want 2", len(xs))
+	}
+	if got, want := xs[0].Object.GetMaterial().Color, Color(0, 0, 1); !got.Equal(want) {
+		t.Errorf("GetMaterial().Color = %v, want %v", got, want)
+	}
+	if got, want := sphere.GetMaterial().Color, Color(1, 1, 1); !got.Equal(want) {
+		t.Errorf("shared sphere GetMaterial().Color = %v, want %v", got, want)
+	}
+}
@@ -12,6 +12,10 @@ type IntersectionT struct {
 
 	U float64
 	V float64
+
+	// Face is the index of the face that was hit for objects made of
+	// many faces, such as meshes.
+	Face int
 }
 
 // Intersection returns an IntersectionT.
@@ -68,6 +72,7 @@ type Comps struct {
 	UnderPoint    Tuple   // For transparency and index of refraction calculations.
 	N1            float64 // Refractive index of material being exited.
 	N2            float64 // Refractive index of material being entered.
+	Medium        Object  // Object being entered by a refracted ray (nil if none).
 }
 
 // PrepareComputations returns a new data structure encapsulating information
@@ -87,6 +92,7 @@ func (i *IntersectionT) PrepareComputations(ray RayT, xs []IntersectionT) *Comps
 	underPoint := point.Sub(eps)
 
 	n1, n2 := 1.0, 1.0
+	var medium Object
 	var containers []Object
 	indexOf := func(x Object) int {
 		for i, c := range containers {
@@ -117,7 +123,8 @@ func (i *IntersectionT) PrepareComputations(ray RayT, xs []IntersectionT) *Comps
 			if len(containers) == 0 {
 				n2 = 1.0
 			} else {
-				n2 = containers[len(containers)-1].GetMaterial().RefractiveIndex
+				medium = containers[len(containers)-1]
+				n2 = medium.GetMaterial().RefractiveIndex
 			}
 			break
 		}
@@ -135,6 +142,7 @@ func (i *IntersectionT) PrepareComputations(ray RayT, xs []IntersectionT) *Comps
 		UnderPoint:    underPoint,
 		N1:            n1,
 		N2:            n2,
+		Medium:        medium,
 	}
 }
 
@@ -159,8 +167,12 @@ func (c *Comps) Schlick() float64 {
 }
 
 // NormalAt returns the normal vector at the given point of intersection with the object.
+// The normal is perturbed by the bump map of the object's material, if any.
 func (hit *IntersectionT) NormalAt(worldPoint Tuple) Tuple {
 	localPoint := WorldToObject(hit.Object, worldPoint)
 	localNormal := hit.Object.LocalNormalAt(localPoint, hit)
+	if bump := hit.Object.GetMaterial().Bump; bump != nil {
+		localNormal = bump.PerturbNormal(hit.Object, localPoint, localNormal.Normalize(), hit)
+	}
 	return NormalToWorld(hit.Object, localNormal)
 }
@@ -114,6 +114,7 @@ func TestIntersectionT_PrepareComputations(t *testing.T) {
 				UnderPoint:    Point(0, 0, -0.9999),
 				N1:            1,
 				N2:            1,
+				Medium:        shape,
 			},
 		},
 		{
@@ -132,6 +133,7 @@ func TestIntersectionT_PrepareComputations(t *testing.T) {
 				UnderPoint:    Point(0, 0, 1.0001),
 				N1:            1,
 				N2:            1,
+				Medium:        shape,
 			},
 		},
 	}
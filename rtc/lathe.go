@@ -0,0 +1,231 @@
+package rtc
+
+import (
+	"log"
+	"math"
+)
+
+// Lathe creates a surface of revolution by revolving the profile polyline
+// around the Y axis. Each profile point holds the radius in X and the
+// height in Y (Z is ignored). The profile should run from bottom to top
+// along the outside of the surface so that its normals point outward;
+// a profile that starts or ends on the axis closes the surface there.
+// Each segment of the profile sweeps out a frustum (or a flat ring) which
+// is intersected analytically.
+// It implements the Object interface.
+func Lathe(profile []Tuple) *LatheT {
+	if len(profile) < 2 {
+		log.Fatalf("programming error - lathe needs at least 2 profile points, got %v", len(profile))
+	}
+
+	l := &LatheT{
+		Shape:   Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Profile: make([]Tuple, len(profile)),
+		bounds:  Bounds(),
+	}
+
+	for i, p := range profile {
+		if p.X() < 0 {
+			log.Fatalf("programming error - lathe profile point %v has negative radius %v", i, p.X())
+		}
+		l.Profile[i] = Point(p.X(), p.Y(), 0)
+		l.bounds.UpdateBounds(Point(-p.X(), p.Y(), -p.X()))
+		l.bounds.UpdateBounds(Point(p.X(), p.Y(), p.X()))
+	}
+	l.computeNormals()
+
+	return l
+}
+
+// LatheSpline creates a smooth surface of revolution from a Catmull-Rom
+// spline through the profile points, which is evaluated at the provided
+// number of steps between each pair of points. Normals are interpolated
+// along the resulting profile so that the surface appears smooth.
+// It implements the Object interface.
+func LatheSpline(points []Tuple, steps int) *LatheT {
+	if len(points) < 2 {
+		log.Fatalf("programming error - lathe spline needs at least 2 points, got %v", len(points))
+	}
+	if steps < 1 {
+		log.Fatalf("programming error - lathe spline needs at least 1 step, got %v", steps)
+	}
+
+	at := func(i int) Tuple {
+		return points[minInt(maxInt(i, 0), len(points)-1)]
+	}
+
+	var profile []Tuple
+	for i := 0; i+1 < len(points); i++ {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		for j := 0; j < steps; j++ {
+			profile = append(profile, catmullRom(p0, p1, p2, p3, float64(j)/float64(steps)))
+		}
+	}
+	profile = append(profile, at(len(points)-1))
+
+	l := Lathe(profile)
+	l.Smooth = true
+	return l
+}
+
+// catmullRom returns the point at t in [0,1] of the uniform Catmull-Rom
+// spline segment between p1 and p2. Radii that overshoot the axis are
+// clamped to it.
+func catmullRom(p0, p1, p2, p3 Tuple, t float64) Tuple {
+	t2, t3 := t*t, t*t*t
+	f := func(v0, v1, v2, v3 float64) float64 {
+		return 0.5 * (2*v1 + (v2-v0)*t + (2*v0-5*v1+4*v2-v3)*t2 + (3*v1-v0-3*v2+v3)*t3)
+	}
+	x := f(p0.X(), p1.X(), p2.X(), p3.X())
+	y := f(p0.Y(), p1.Y(), p2.Y(), p3.Y())
+	return Point(math.Max(0, x), y, 0)
+}
+
+// LatheT represents a surface of revolution.
+// The U value of each intersection is the position along the profile
+// segment given by its Face.
+type LatheT struct {
+	Shape
+	Profile []Tuple
+
+	// Smooth reports whether normals are interpolated between the profile
+	// points instead of being constant along each segment.
+	Smooth bool
+
+	// normals holds the outward normal (radial in X, height in Y) of each
+	// segment followed by the averaged normal at each profile point.
+	normals []Tuple
+	bounds  *BoundsT
+}
+
+var _ Object = &LatheT{}
+
+// computeNormals computes the profile normals of the segments and points.
+func (l *LatheT) computeNormals() {
+	n := len(l.Profile) - 1
+	l.normals = make([]Tuple, 2*n+1)
+	for i := 0; i < n; i++ {
+		d := l.Profile[i+1].Sub(l.Profile[i])
+		normal := Vector(d.Y(), -d.X(), 0)
+		if normal.Magnitude() > 0 {
+			normal = normal.Normalize()
+		}
+		l.normals[i] = normal
+	}
+	for i := 0; i <= n; i++ {
+		sum := Vector(0, 0, 0)
+		if i > 0 {
+			sum = sum.Add(l.normals[i-1])
+		}
+		if i < n {
+			sum = sum.Add(l.normals[i])
+		}
+		if sum.Magnitude() > 0 {
+			sum = sum.Normalize()
+		}
+		l.normals[n+i] = sum
+	}
+}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (l *LatheT) SetTransform(m M4) Object {
+	l.Transform = m
+	return l
+}
+
+// SetMaterial sets the object's material.
+func (l *LatheT) SetMaterial(material MaterialT) Object {
+	l.setMaterial(material)
+	return l
+}
+
+// SetParent sets the object's parent object.
+func (l *LatheT) SetParent(parent Object) Object {
+	l.Parent = parent
+	return l
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (l *LatheT) Bounds() *BoundsT {
+	return l.bounds
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+func (l *LatheT) LocalIntersect(ray RayT) []IntersectionT {
+	if len(l.bounds.LocalIntersect(ray, l)) == 0 {
+		return nil
+	}
+
+	ox, oy, oz := ray.Origin.X(), ray.Origin.Y(), ray.Origin.Z()
+	dx, dy, dz := ray.Direction.X(), ray.Direction.Y(), ray.Direction.Z()
+
+	var xs []IntersectionT
+	last := len(l.Profile) - 2
+	// Each profile point belongs to the segment starting at it, except for
+	// the final point, so hits on shared points are not duplicated.
+	inSegment := func(i int, s float64) bool {
+		return s >= 0 && (s < 1 || (i == last && s <= 1))
+	}
+
+	for i := 0; i <= last; i++ {
+		r0, y0 := l.Profile[i].X(), l.Profile[i].Y()
+		r1, y1 := l.Profile[i+1].X(), l.Profile[i+1].Y()
+
+		if math.Abs(y1-y0) < eqnEpsilon {
+			// A flat ring in the plane y = y0.
+			if math.Abs(dy) < eqnEpsilon || math.Abs(r1-r0) < eqnEpsilon {
+				continue
+			}
+			t := (y0 - oy) / dy
+			x, z := ox+t*dx, oz+t*dz
+			if s := (math.Sqrt(x*x+z*z) - r0) / (r1 - r0); inSegment(i, s) {
+				xs = append(xs, IntersectionT{T: t, Object: l, U: s, Face: i})
+			}
+			continue
+		}
+
+		// The radius along the segment is a linear function of height:
+		// r(y) = r0 + k*(y-y0), and along the ray r(t) = a + b*t.
+		k := (r1 - r0) / (y1 - y0)
+		a := r0 + k*(oy-y0)
+		b := k * dy
+
+		c2 := dx*dx + dz*dz - b*b
+		c1 := 2 * (ox*dx + oz*dz - a*b)
+		c0 := ox*ox + oz*oz - a*a
+		for _, t := range solveQuadratic(c2, c1, c0) {
+			if a+b*t < 0 {
+				continue // the mirrored nappe of the cone
+			}
+			if s := (oy + t*dy - y0) / (y1 - y0); inSegment(i, s) {
+				xs = append(xs, IntersectionT{T: t, Object: l, U: s, Face: i})
+			}
+		}
+	}
+
+	return Intersections(xs...)
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+func (l *LatheT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	n := l.normals[hit.Face]
+	if l.Smooth {
+		first, second := l.normals[len(l.Profile)-1+hit.Face], l.normals[len(l.Profile)+hit.Face]
+		n = first.MultScalar(1 - hit.U).Add(second.MultScalar(hit.U))
+	}
+
+	radial := Vector(objectPoint.X(), 0, objectPoint.Z())
+	if m := radial.Magnitude(); m > eqnEpsilon {
+		radial = radial.DivScalar(m)
+	}
+	return radial.MultScalar(n.X()).Add(Vector(0, n.Y(), 0))
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (l *LatheT) Includes(other Object) bool {
+	return l == other
+}
@@ -0,0 +1,108 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLatheT_LocalIntersect(t *testing.T) {
+	cup := Lathe([]Tuple{Point(0, 0, 0), Point(1, 0, 0), Point(0.5, 1, 0), Point(0, 1, 0)})
+	tube := Lathe([]Tuple{Point(1, 0, 0), Point(1, 1, 0)})
+	spline := LatheSpline([]Tuple{Point(1, 0, 0), Point(1.2, 0.5, 0), Point(1, 1, 0)}, 4)
+
+	tests := []struct {
+		name        string
+		lathe       *LatheT
+		ray         RayT
+		wantT       []float64
+		wantNormals []Tuple
+	}{
+		{
+			name:        "tube",
+			lathe:       tube,
+			ray:         Ray(Point(0, 0.5, -5), Vector(0, 0, 1)),
+			wantT:       []float64{4, 6},
+			wantNormals: []Tuple{Vector(0, 0, -1), Vector(0, 0, 1)},
+		},
+		{
+			name:  "tube miss beyond the end",
+			lathe: tube,
+			ray:   Ray(Point(0, 1.5, -5), Vector(0, 0, 1)),
+		},
+		{
+			name:  "open tube along the axis",
+			lathe: tube,
+			ray:   Ray(Point(0, -5, 0), Vector(0, 1, 0)),
+		},
+		{
+			name:        "cup along the axis",
+			lathe:       cup,
+			ray:         Ray(Point(0, -5, 0), Vector(0, 1, 0)),
+			wantT:       []float64{5, 6},
+			wantNormals: []Tuple{Vector(0, -1, 0), Vector(0, 1, 0)},
+		},
+		{
+			name:        "cup frustum side",
+			lathe:       cup,
+			ray:         Ray(Point(0, 0.5, -5), Vector(0, 0, 1)),
+			wantT:       []float64{4.25, 5.75},
+			wantNormals: []Tuple{Vector(0, 1, -2).Normalize(), Vector(0, 1, 2).Normalize()},
+		},
+		{
+			name:  "cup miss",
+			lathe: cup,
+			ray:   Ray(Point(2, 0.5, -5), Vector(0, 0, 1)),
+		},
+		{
+			name:        "smooth spline at a profile point",
+			lathe:       spline,
+			ray:         Ray(Point(0, 0.5, -5), Vector(0, 0, 1)),
+			wantT:       []float64{3.8, 6.2},
+			wantNormals: []Tuple{Vector(0, 0, -1), Vector(0, 0, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xs := tt.lathe.LocalIntersect(tt.ray)
+			if len(xs) != len(tt.wantT) {
+				t.Fatalf("LocalIntersect = %v, want t = %v", xs, tt.wantT)
+			}
+			for i, want := range tt.wantT {
+				if math.Abs(xs[i].T-want) > epsilon {
+					t.Errorf("xs[%v].T = %v, want %v", i, xs[i].T, want)
+				}
+				n := tt.lathe.LocalNormalAt(tt.ray.Position(xs[i].T), &xs[i]).Normalize()
+				if !n.Equal(tt.wantNormals[i]) {
+					t.Errorf("normal[%v] = %v, want %v", i, n, tt.wantNormals[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLatheT_Bounds(t *testing.T) {
+	l := Lathe([]Tuple{Point(0, -1, 0), Point(2, 0, 0), Point(0.5, 3, 0)})
+	b := l.Bounds()
+	if want := Point(-2, -1, -2); !b.Min.Equal(want) {
+		t.Errorf("Bounds().Min = %v, want %v", b.Min, want)
+	}
+	if want := Point(2, 3, 2); !b.Max.Equal(want) {
+		t.Errorf("Bounds().Max = %v, want %v", b.Max, want)
+	}
+}
+
+func TestLatheSpline(t *testing.T) {
+	l := LatheSpline([]Tuple{Point(1, 0, 0), Point(1.2, 0.5, 0), Point(1, 1, 0)}, 4)
+	if got, want := len(l.Profile), 9; got != want {
+		t.Fatalf("len(Profile) = %v, want %v", got, want)
+	}
+	if !l.Smooth {
+		t.Errorf("Smooth = false, want true")
+	}
+	for i, want := range []Tuple{Point(1, 0, 0), Point(1.2, 0.5, 0), Point(1, 1, 0)} {
+		if got := l.Profile[4*i]; !got.Equal(want) {
+			t.Errorf("Profile[%v] = %v, want %v", 4*i, got, want)
+		}
+	}
+}
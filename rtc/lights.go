@@ -1,7 +1,5 @@
 package rtc
 
-import "math"
-
 // PointLightT represents a point light.
 type PointLightT struct {
 	position  Tuple
@@ -16,37 +14,57 @@ func PointLight(position Tuple, intensity Tuple) *PointLightT {
 
 // Lighting calculates the lighting on an object and returns the color as a Tuple.
 func Lighting(material *MaterialT, object Object, light *PointLightT, point Tuple, eyeVector Tuple, normalVector Tuple, inShadow bool) Tuple {
-	color := material.Color
-	if material.Pattern != nil {
-		color = PatternAt(material.Pattern, object, point)
+	transmittance := Color(1, 1, 1)
+	if inShadow {
+		transmittance = Color(0, 0, 0)
 	}
+	return lighting(material, object, light, point, eyeVector, normalVector, transmittance, 1)
+}
 
-	effectiveColor := color.HadamardProduct(light.intensity)
-
-	lightV := light.position.Sub(point).Normalize()
+// lighting calculates the lighting like Lighting with the light reaching
+// the point attenuated by transmittance (black when in shadow) and the
+// ambient term scaled by ambientScale (such as the result of ambient
+// occlusion).
+func lighting(material *MaterialT, object Object, light *PointLightT, point Tuple, eyeVector Tuple, normalVector Tuple, transmittance Tuple, ambientScale float64) Tuple {
+	color := surfaceColor(material, object, point)
 
-	ambient := effectiveColor.MultScalar(material.Ambient)
+	ambient := color.HadamardProduct(light.intensity).MultScalar(material.Ambient * ambientScale)
 
-	if inShadow {
+	if transmittance.Equal(Color(0, 0, 0)) {
 		return ambient
 	}
 
-	lightDotNormal := lightV.Dot(normalVector)
+	intensity := light.intensity.HadamardProduct(transmittance)
+	return ambient.Add(directLighting(material, color, light.position, intensity, point, eyeVector, normalVector))
+}
 
-	if lightDotNormal < 0 {
-		return ambient
+// surfaceColor returns the material color at the given world point,
+// evaluating its pattern if it has one.
+func surfaceColor(material *MaterialT, object Object, point Tuple) Tuple {
+	if material.Pattern != nil {
+		return PatternAt(material.Pattern, object, point)
 	}
+	return material.Color
+}
 
-	diffuse := effectiveColor.MultScalar(material.Diffuse * lightDotNormal)
+// directLighting calculates the diffuse and specular contribution (without
+// the ambient term) of a light of the given intensity located at position.
+func directLighting(material *MaterialT, color, position, intensity, point, eyeVector, normalVector Tuple) Tuple {
+	lightV := position.Sub(point).Normalize()
+	return directionalLighting(material, color, lightV, intensity, eyeVector, normalVector)
+}
 
-	reflectV := lightV.Negate().Reflect(normalVector)
-	reflectDotEye := reflectV.Dot(eyeVector)
+// directionalLighting calculates the diffuse and specular contribution of
+// light of the given intensity arriving from the (unit) light vector.
+func directionalLighting(material *MaterialT, color, lightV, intensity, eyeVector, normalVector Tuple) Tuple {
+	if lightV.Dot(normalVector) < 0 {
+		return Color(0, 0, 0)
+	}
 
-	specular := Color(0, 0, 0)
-	if reflectDotEye > 0 {
-		factor := math.Pow(reflectDotEye, material.Shininess)
-		specular = light.intensity.MultScalar(material.Specular * factor)
+	model := material.Model
+	if model == nil {
+		model = Phong()
 	}
 
-	return ambient.Add(diffuse).Add(specular)
+	return model.Shade(material, color, intensity, lightV, eyeVector, normalVector)
 }
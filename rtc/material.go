@@ -1,5 +1,7 @@
 package rtc
 
+import "math"
+
 // MaterialT represents a material.
 type MaterialT struct {
 	Color           Tuple
@@ -11,18 +13,81 @@ type MaterialT struct {
 	Transparency    float64
 	RefractiveIndex float64
 	Pattern         Pattern
+
+	// Bump perturbs the normals of the surface to add detail without
+	// changing its geometry. A nil Bump leaves the normals unchanged.
+	Bump BumpMap
+
+	// AbsorptionColor is the color that light tends toward as it travels
+	// through a transparent material, and AbsorptionDensity controls how
+	// quickly (per unit of world distance) it does so, following the
+	// Beer-Lambert law. A zero AbsorptionDensity disables absorption.
+	AbsorptionColor   Tuple
+	AbsorptionDensity float64
+
+	// Volume reports whether the material fills its shape with a
+	// homogeneous participating medium (such as smoke, haze or dusty air)
+	// instead of giving it a visible surface. Rays pass through the
+	// boundary of the shape, and light from the lights of the world is
+	// scattered toward the eye along the way through the medium.
+	// VolumeScattering and VolumeAbsorption are the scattering and
+	// absorption coefficients (per color channel and per unit of world
+	// distance) of the medium, and VolumeAnisotropy is the
+	// Henyey-Greenstein asymmetry of its scattering, from -1 (backward)
+	// through 0 (isotropic) to 1 (forward).
+	Volume           bool
+	VolumeScattering Tuple
+	VolumeAbsorption Tuple
+	VolumeAnisotropy float64
+
+	// Emission is the color emitted by the material regardless of any
+	// lights in the scene.
+	Emission Tuple
+
+	// Model is the shading model (BRDF) used for direct lighting.
+	// A nil Model uses the book's Phong model.
+	Model ShadingModel
+	// Metallic and Roughness parameterize physically based shading models
+	// such as CookTorrance and are ignored by the Phong models.
+	Metallic  float64
+	Roughness float64
 }
 
 // Material returns a default material.
 func GetMaterial() MaterialT {
 	return MaterialT{
-		Color:           Color(1, 1, 1),
-		Ambient:         0.1,
-		Diffuse:         0.9,
-		Specular:        0.9,
-		Shininess:       200.0,
-		Reflective:      0,
-		Transparency:    0,
-		RefractiveIndex: 1,
+		Color:            Color(1, 1, 1),
+		Ambient:          0.1,
+		Diffuse:          0.9,
+		Specular:         0.9,
+		Shininess:        200.0,
+		Reflective:       0,
+		Transparency:     0,
+		RefractiveIndex:  1,
+		AbsorptionColor:  Color(1, 1, 1),
+		VolumeScattering: Color(0, 0, 0),
+		VolumeAbsorption: Color(0, 0, 0),
+		Emission:         Color(0, 0, 0),
+		Metallic:         0,
+		Roughness:        0.5,
+	}
+}
+
+// Transmittance returns the fraction of light (per color channel) that
+// survives traveling the given distance through the material.
+func (m *MaterialT) Transmittance(distance float64) Tuple {
+	if m.AbsorptionDensity <= 0 {
+		return Color(1, 1, 1)
+	}
+
+	f := func(c float64) float64 {
+		// Channels that are not absorbed are fully transmitted, even over
+		// an infinite distance (such as a refracted ray that escapes).
+		k := (1 - c) * m.AbsorptionDensity
+		if k == 0 {
+			return 1
+		}
+		return math.Exp(-k * distance)
 	}
+	return Color(f(m.AbsorptionColor.Red()), f(m.AbsorptionColor.Green()), f(m.AbsorptionColor.Blue()))
 }
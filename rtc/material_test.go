@@ -1,6 +1,7 @@
 package rtc
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -22,6 +23,10 @@ func TestMaterial(t *testing.T) {
 				Reflective:      0,
 				Transparency:    0,
 				RefractiveIndex: 1,
+				AbsorptionColor: Color(1, 1, 1),
+				Emission:        Color(0, 0, 0),
+				Metallic:        0,
+				Roughness:       0.5,
 			},
 		},
 	}
@@ -34,7 +39,13 @@ func TestMaterial(t *testing.T) {
 			a.Shininess == b.Shininess &&
 			a.Reflective == b.Reflective &&
 			a.Transparency == b.Transparency &&
-			a.RefractiveIndex == b.RefractiveIndex
+			a.RefractiveIndex == b.RefractiveIndex &&
+			a.AbsorptionColor == b.AbsorptionColor &&
+			a.AbsorptionDensity == b.AbsorptionDensity &&
+			a.Emission == b.Emission &&
+			a.Model == b.Model &&
+			a.Metallic == b.Metallic &&
+			a.Roughness == b.Roughness
 	})
 
 	for _, tt := range tests {
@@ -47,3 +58,61 @@ func TestMaterial(t *testing.T) {
 		})
 	}
 }
+
+func TestMaterialT_Transmittance(t *testing.T) {
+	tests := []struct {
+		name     string
+		color    Tuple
+		density  float64
+		distance float64
+		want     Tuple
+	}{
+		{
+			name:     "A material without absorption transmits all light",
+			color:    Color(1, 0.5, 0),
+			density:  0,
+			distance: 10,
+			want:     Color(1, 1, 1),
+		},
+		{
+			name:     "A white absorption color transmits all light",
+			color:    Color(1, 1, 1),
+			density:  2,
+			distance: 10,
+			want:     Color(1, 1, 1),
+		},
+		{
+			name:     "Absorption increases with distance",
+			color:    Color(1, 0.5, 0),
+			density:  1,
+			distance: 1,
+			want:     Color(1, math.Exp(-0.5), math.Exp(-1)),
+		},
+		{
+			name:     "Absorption increases with density",
+			color:    Color(1, 0.5, 0),
+			density:  2,
+			distance: 1,
+			want:     Color(1, math.Exp(-1), math.Exp(-2)),
+		},
+		{
+			name:     "Only absorbed channels vanish over an infinite distance",
+			color:    Color(1, 0.5, 0),
+			density:  1,
+			distance: math.Inf(1),
+			want:     Color(1, 0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := GetMaterial()
+			m.AbsorptionColor = tt.color
+			m.AbsorptionDensity = tt.density
+
+			if got := m.Transmittance(tt.distance); !got.Equal(tt.want) {
+				t.Errorf("Transmittance(%v) = %v, want %v", tt.distance, got, tt.want)
+			}
+		})
+	}
+}
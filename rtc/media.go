@@ -0,0 +1,267 @@
+package rtc
+
+import "math"
+
+// FogMode selects how the amount of fog grows with distance.
+type FogMode int
+
+const (
+	// LinearFogMode blends linearly from no fog at Start to full fog at End.
+	LinearFogMode FogMode = iota
+	// ExponentialFogMode blends exponentially with Density per unit of
+	// distance.
+	ExponentialFogMode
+)
+
+// FogT represents distance fog, which blends the color seen along every
+// ray toward the fog color with the distance traveled by the ray.
+// Rays that miss every object are fully fogged.
+type FogT struct {
+	Mode  FogMode
+	Color Tuple
+
+	// Start and End are the distances of the linear fog.
+	Start float64
+	End   float64
+	// Density is the density of the exponential fog.
+	Density float64
+}
+
+// LinearFog returns fog of the provided color that starts at the start
+// distance and completely hides everything beyond the end distance.
+func LinearFog(color Tuple, start, end float64) *FogT {
+	return &FogT{Mode: LinearFogMode, Color: color, Start: start, End: end}
+}
+
+// ExponentialFog returns fog of the provided color and density.
+func ExponentialFog(color Tuple, density float64) *FogT {
+	return &FogT{Mode: ExponentialFogMode, Color: color, Density: density}
+}
+
+// Amount returns the fraction (in [0,1]) of the fog color seen at the
+// provided distance.
+func (f *FogT) Amount(distance float64) float64 {
+	if math.IsInf(distance, 1) {
+		return 1
+	}
+
+	switch f.Mode {
+	case ExponentialFogMode:
+		return 1 - math.Exp(-f.Density*distance)
+	default:
+		if f.End <= f.Start {
+			if distance < f.Start {
+				return 0
+			}
+			return 1
+		}
+		return math.Max(0, math.Min(1, (distance-f.Start)/(f.End-f.Start)))
+	}
+}
+
+// Apply blends the color seen at the provided distance with the fog.
+func (f *FogT) Apply(color Tuple, distance float64) Tuple {
+	amount := f.Amount(distance)
+	return color.MultScalar(1 - amount).Add(f.Color.MultScalar(amount))
+}
+
+// defaultVolumeSteps is the number of steps used to integrate the light
+// scattered within each span of a participating medium when the world
+// does not specify one.
+const defaultVolumeSteps = 16
+
+// isVolume reports whether the object is the invisible boundary of a
+// participating medium.
+func isVolume(object Object) bool {
+	return object.GetMaterial().Volume
+}
+
+// surfaceHit returns the visible hit from a sorted list of intersections,
+// skipping the boundaries of participating media.
+func surfaceHit(xs []IntersectionT) *IntersectionT {
+	for _, x := range xs {
+		if x.T > 0 && !isVolume(x.Object) {
+			return &x
+		}
+	}
+	return nil
+}
+
+// mediumSpan is a span of a ray within the same set of participating media.
+type mediumSpan struct {
+	start, end float64
+	volumes    []Object
+}
+
+// mediumSpans returns the spans of the ray between 0 and distance that lie
+// within participating media, based on the sorted intersections of the
+// ray with the world.
+func mediumSpans(xs []IntersectionT, distance float64) []mediumSpan {
+	var spans []mediumSpan
+	var volumes []Object
+	start := 0.0
+	addSpan := func(end float64) {
+		end = math.Min(end, distance)
+		if len(volumes) > 0 && end > start {
+			spans = append(spans, mediumSpan{start: start, end: end, volumes: append([]Object(nil), volumes...)})
+		}
+		start = math.Max(start, end)
+	}
+
+	for _, x := range xs {
+		if !isVolume(x.Object) {
+			continue
+		}
+		if x.T > 0 {
+			if x.T >= distance {
+				break
+			}
+			addSpan(x.T)
+		}
+
+		if index := indexOfObject(volumes, x.Object); index >= 0 {
+			volumes = append(volumes[:index], volumes[index+1:]...)
+		} else {
+			volumes = append(volumes, x.Object)
+		}
+	}
+	addSpan(distance)
+	return spans
+}
+
+// indexOfObject returns the index of the object in the slice, or -1.
+func indexOfObject(objects []Object, object Object) int {
+	for i, o := range objects {
+		if o == object {
+			return i
+		}
+	}
+	return -1
+}
+
+// coefficients returns the combined scattering and extinction coefficients
+// of the volumes along with the anisotropy of the innermost one.
+func (s *mediumSpan) coefficients() (Tuple, Tuple, float64) {
+	scattering, extinction := Color(0, 0, 0), Color(0, 0, 0)
+	for _, v := range s.volumes {
+		m := v.GetMaterial()
+		scattering = scattering.Add(m.VolumeScattering)
+		extinction = extinction.Add(m.VolumeScattering).Add(m.VolumeAbsorption)
+	}
+	return scattering, extinction, s.volumes[len(s.volumes)-1].GetMaterial().VolumeAnisotropy
+}
+
+// beerLambert returns the per-channel transmittance through the given
+// extinction coefficients over the distance.
+func beerLambert(extinction Tuple, distance float64) Tuple {
+	return Color(
+		math.Exp(-extinction.Red()*distance),
+		math.Exp(-extinction.Green()*distance),
+		math.Exp(-extinction.Blue()*distance),
+	)
+}
+
+// henyeyGreenstein returns the Henyey-Greenstein phase function for the
+// cosine of the scattering angle and the anisotropy g, which ranges from
+// -1 (back scattering) through 0 (isotropic) to 1 (forward scattering).
+func henyeyGreenstein(cosTheta, g float64) float64 {
+	denom := 1 + g*g - 2*g*cosTheta
+	return (1 - g*g) / (4 * math.Pi * denom * math.Sqrt(denom))
+}
+
+// transmittanceBetween returns the fraction of light (per color channel)
+// that travels from position to point through the participating media of
+// the world, or black if a visible surface lies between them. Surfaces
+// within tolerance of position (e.g. the surface of a geometry light that
+// position was sampled from) do not block the light.
+func (w *WorldT) transmittanceBetween(point, position Tuple, tolerance float64) Tuple {
+	v := position.Sub(point)
+	distance := v.Magnitude()
+	xs := w.IntersectWorld(Ray(point, v.Normalize()))
+	if h := surfaceHit(xs); h != nil && h.T < distance-tolerance {
+		return Color(0, 0, 0)
+	}
+
+	transmittance := Color(1, 1, 1)
+	for _, span := range mediumSpans(xs, distance) {
+		_, extinction, _ := span.coefficients()
+		transmittance = transmittance.HadamardProduct(beerLambert(extinction, span.end-span.start))
+	}
+	return transmittance
+}
+
+// applyMedia returns the color seen along the ray through the
+// participating media of the world in front of the provided color, which
+// is seen at the given distance. Light from the point lights and geometry
+// lights of the world is scattered toward the eye once along the way.
+func (w *WorldT) applyMedia(ray RayT, xs []IntersectionT, distance float64, color Tuple) Tuple {
+	spans := mediumSpans(xs, distance)
+	if len(spans) == 0 {
+		return color
+	}
+
+	steps := w.VolumeSteps
+	if steps < 1 {
+		steps = defaultVolumeSteps
+	}
+	direction := ray.Direction.Normalize()
+	scale := ray.Direction.Magnitude()
+
+	transmittance := Color(1, 1, 1)
+	scattered := Color(0, 0, 0)
+	for _, span := range spans {
+		scattering, extinction, g := span.coefficients()
+		end := span.end
+		if math.IsInf(end, 1) {
+			// Media without end (such as infinite planes) are integrated far
+			// enough for their light to have been almost entirely extinguished.
+			end = span.start + 10/math.Max(eqnEpsilon, math.Min(extinction.Red(), math.Min(extinction.Green(), extinction.Blue())))/scale
+		}
+
+		dt := (end - span.start) / float64(steps)
+		stepTransmittance := beerLambert(extinction, dt*scale)
+		// The fraction of light scattered toward the eye within a step,
+		// integrating the transmittance across the step.
+		stepScattering := Color(0, 0, 0)
+		for i := 0; i < 3; i++ {
+			if extinction[i] > 0 {
+				stepScattering[i] = scattering[i] / extinction[i] * (1 - stepTransmittance[i])
+			}
+		}
+
+		for i := 0; i < steps; i++ {
+			point := ray.Position(span.start + (float64(i)+0.5)*dt)
+			inScattered := w.inScattered(point, direction, g)
+			scattered = scattered.Add(transmittance.HadamardProduct(stepScattering).HadamardProduct(inScattered))
+			transmittance = transmittance.HadamardProduct(stepTransmittance)
+		}
+	}
+
+	return scattered.Add(transmittance.HadamardProduct(color))
+}
+
+// inScattered returns the light from the lights of the world arriving at
+// the point within a medium that is scattered along the (unit) direction
+// of the ray, per unit of scattering.
+func (w *WorldT) inScattered(point, direction Tuple, g float64) Tuple {
+	result := Color(0, 0, 0)
+	add := func(position, intensity Tuple, tolerance float64) {
+		transmittance := w.transmittanceBetween(point, position, tolerance)
+		if transmittance.Equal(Color(0, 0, 0)) {
+			return
+		}
+		cosTheta := position.Sub(point).Normalize().Dot(direction)
+		light := intensity.HadamardProduct(transmittance).MultScalar(henyeyGreenstein(cosTheta, g))
+		result = result.Add(light)
+	}
+
+	for _, light := range w.Lights {
+		add(light.position, light.intensity, 0)
+	}
+	for _, light := range w.GeometryLights {
+		for _, sample := range light.samples() {
+			add(sample.position, sample.intensity, epsilon)
+		}
+	}
+	return result
+}
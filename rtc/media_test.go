@@ -0,0 +1,234 @@
+package rtc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestFogT_Amount(t *testing.T) {
+	tests := []struct {
+		fog      *FogT
+		distance float64
+		want     float64
+	}{
+		{fog: LinearFog(Color(1, 1, 1), 10, 20), distance: 5, want: 0},
+		{fog: LinearFog(Color(1, 1, 1), 10, 20), distance: 15, want: 0.5},
+		{fog: LinearFog(Color(1, 1, 1), 10, 20), distance: 25, want: 1},
+		{fog: LinearFog(Color(1, 1, 1), 10, 20), distance: math.Inf(1), want: 1},
+		{fog: LinearFog(Color(1, 1, 1), 10, 10), distance: 9, want: 0},
+		{fog: ExponentialFog(Color(1, 1, 1), 0.5), distance: 0, want: 0},
+		{fog: ExponentialFog(Color(1, 1, 1), 0.5), distance: 2, want: 1 - math.Exp(-1)},
+		{fog: ExponentialFog(Color(1, 1, 1), 0.5), distance: math.Inf(1), want: 1},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test #%v", i), func(t *testing.T) {
+			if got := tt.fog.Amount(tt.distance); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("Amount(%v) = %v, want %v", tt.distance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorldT_ColorAt_Fog(t *testing.T) {
+	w := World()
+	s := Sphere()
+	s.GetMaterial().Color = Color(0, 0, 0)
+	s.GetMaterial().Ambient = 0
+	s.GetMaterial().Specular = 0
+	w.Objects = []Object{s}
+	w.Fog = LinearFog(Color(0.5, 0.6, 0.7), 0, 8)
+
+	// The sphere is hit at a distance of 4, which is half way into the fog.
+	r := Ray(Point(0, 0, -5), Vector(0, 0, 1))
+	if got, want := w.ColorAt(r, maxReflections), Color(0.25, 0.3, 0.35); !got.Equal(want) {
+		t.Errorf("ColorAt hit = %v, want %v", got, want)
+	}
+
+	// Rays that miss are completely fogged.
+	r = Ray(Point(0, 0, -5), Vector(0, 1, 0))
+	if got, want := w.ColorAt(r, maxReflections), Color(0.5, 0.6, 0.7); !got.Equal(want) {
+		t.Errorf("ColorAt miss = %v, want %v", got, want)
+	}
+}
+
+func volumeSphere(scattering, absorption float64) *SphereT {
+	s := Sphere()
+	m := s.GetMaterial()
+	m.Volume = true
+	m.VolumeScattering = Color(scattering, scattering, scattering)
+	m.VolumeAbsorption = Color(absorption, absorption, absorption)
+	return s
+}
+
+func TestMediumSpans(t *testing.T) {
+	outer := volumeSphere(0, 1)
+	outer.SetTransform(Scaling(2, 2, 2))
+	inner := volumeSphere(0, 1)
+	solid := Sphere()
+	solid.SetTransform(Translation(0, 0, 1.5).Mult(Scaling(0.25, 0.25, 0.25)))
+
+	w := World()
+	w.Objects = []Object{outer, inner, solid}
+
+	tests := []struct {
+		name     string
+		ray      RayT
+		distance float64
+		want     []mediumSpan
+	}{
+		{
+			name:     "through nested volumes",
+			ray:      Ray(Point(0, 0, -5), Vector(0, 0, 1)),
+			distance: 6.25,
+			want: []mediumSpan{
+				{start: 3, end: 4, volumes: []Object{outer}},
+				{start: 4, end: 6, volumes: []Object{outer, inner}},
+				{start: 6, end: 6.25, volumes: []Object{outer}},
+			},
+		},
+		{
+			name:     "from inside a volume",
+			ray:      Ray(Point(0, 0, 0), Vector(0, 1, 0)),
+			distance: math.Inf(1),
+			want: []mediumSpan{
+				{start: 0, end: 1, volumes: []Object{outer, inner}},
+				{start: 1, end: 2, volumes: []Object{outer}},
+			},
+		},
+		{
+			name:     "missing all volumes",
+			ray:      Ray(Point(0, 5, -5), Vector(0, 0, 1)),
+			distance: math.Inf(1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mediumSpans(w.IntersectWorld(tt.ray), tt.distance)
+			if len(got) != len(tt.want) {
+				t.Fatalf("mediumSpans = %v, want %v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				span := got[i]
+				if math.Abs(span.start-want.start) > epsilon || math.Abs(span.end-want.end) > epsilon {
+					t.Errorf("span[%v] = [%v,%v], want [%v,%v]", i, span.start, span.end, want.start, want.end)
+				}
+				if len(span.volumes) != len(want.volumes) {
+					t.Errorf("span[%v] volumes = %v, want %v", i, span.volumes, want.volumes)
+					continue
+				}
+				for j := range want.volumes {
+					if span.volumes[j] != want.volumes[j] {
+						t.Errorf("span[%v].volumes[%v] = %v, want %v", i, j, span.volumes[j], want.volumes[j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWorldT_ColorAt_Volume(t *testing.T) {
+	// An absorbing volume in front of a white background.
+	w := World()
+	w.Environment = SolidEnvironment(Color(1, 1, 1))
+	w.Objects = []Object{volumeSphere(0, 0.5)}
+
+	r := Ray(Point(0, 0, -5), Vector(0, 0, 1))
+	if got, want := w.ColorAt(r, maxReflections), Color(math.Exp(-1), math.Exp(-1), math.Exp(-1)); !got.Equal(want) {
+		t.Errorf("ColorAt absorbing volume = %v, want %v", got, want)
+	}
+
+	// A scattering volume glows when lit, and less so in a shadow.
+	w = World()
+	w.Objects = []Object{volumeSphere(0.5, 0)}
+	w.Lights = []*PointLightT{PointLight(Point(0, 10, 0), Color(10, 10, 10))}
+
+	lit := w.ColorAt(r, maxReflections)
+	if lit.Red() <= 0 {
+		t.Fatalf("ColorAt lit volume = %v, want glowing", lit)
+	}
+
+	blocker := Cube()
+	blocker.SetTransform(Translation(0, 5, 0).Mult(Scaling(2, 0.1, 2)))
+	w.Objects = append(w.Objects, blocker)
+	if got := w.ColorAt(r, maxReflections); got.Red() >= lit.Red()/10 {
+		t.Errorf("ColorAt shadowed volume = %v, want much darker than %v", got, lit)
+	}
+}
+
+func TestWorldT_ColorAt_InsideVolume(t *testing.T) {
+	// The boundary of a volume does not shadow the surfaces inside it.
+	floor := Plane()
+	floor.GetMaterial().Specular = 0
+	fog := Cube()
+	fog.SetTransform(Scaling(10, 10, 10))
+	fog.GetMaterial().Volume = true
+
+	w := World()
+	w.Objects = []Object{floor, fog}
+	w.Lights = []*PointLightT{PointLight(Point(0, 5, 0), Color(1, 1, 1))}
+
+	r := Ray(Point(0, 1, 0), Vector(0, -1, 0))
+	if got, want := w.ColorAt(r, maxReflections), Color(1, 1, 1); !got.Equal(want) {
+		t.Errorf("ColorAt = %v, want %v", got, want)
+	}
+}
+
+func TestWorldT_ShadeHit_InsideAbsorbingVolume(t *testing.T) {
+	floor := Plane()
+	floor.GetMaterial().Ambient = 0
+	floor.GetMaterial().Specular = 0
+	smoke := Cube()
+	smoke.SetTransform(Scaling(10, 10, 10))
+	smoke.GetMaterial().Volume = true
+	smoke.GetMaterial().VolumeAbsorption = Color(0.5, 0.25, 0)
+
+	emitter := Quad(Point(-0.5, 2, -0.5), Vector(1, 0, 0), Vector(0, 0, 1))
+	emitter.GetMaterial().Emission = Color(1, 1, 1)
+	geometryLight := GeometryLight(emitter, 1, 1)
+	geometryLight.Jitter = false
+
+	// The light travels a distance of 2 through the medium to the floor.
+	transmittance := Color(math.Exp(-1), math.Exp(-0.5), 1)
+
+	tests := []struct {
+		name  string
+		setup func(w *WorldT)
+	}{
+		{
+			name: "point light",
+			setup: func(w *WorldT) {
+				w.Lights = []*PointLightT{PointLight(Point(0, 2, 0), Color(1, 1, 1))}
+			},
+		},
+		{
+			name: "geometry light",
+			setup: func(w *WorldT) {
+				w.Objects = append(w.Objects, emitter)
+				w.GeometryLights = []*GeometryLightT{geometryLight}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Ray(Point(0, 1, 0), Vector(0, -1, 0))
+			xs := Intersections(Intersection(1, floor))
+			comps := xs[0].PrepareComputations(r, xs)
+
+			w := World()
+			w.Objects = []Object{floor}
+			tt.setup(w)
+			lit := w.ShadeHit(comps, maxReflections)
+
+			smoky := World()
+			smoky.Objects = []Object{floor, smoke}
+			tt.setup(smoky)
+			if got, want := smoky.ShadeHit(comps, maxReflections), lit.HadamardProduct(transmittance); !got.Equal(want) {
+				t.Errorf("ShadeHit = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,415 @@
+package rtc
+
+import (
+	"log"
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	// meshLeafSize is the maximum number of faces in a leaf of a mesh BVH.
+	meshLeafSize = 4
+)
+
+// MeshFace is a triangle of a MeshT that refers to the shared vertex,
+// normal, and texture coordinate arrays of the mesh by (0-based) index.
+// Indices are 32 bits to keep large meshes compact.
+type MeshFace struct {
+	Vertices [3]int32
+	// Normals are the vertex normal indices of a smooth face, or -1 for a
+	// flat face.
+	Normals [3]int32
+	// TexCoords are the texture coordinate indices of the face, or -1 if
+	// the face has none.
+	TexCoords [3]int32
+	// Material is the index of the per-face material of the mesh, or -1 to
+	// use the material of the mesh itself.
+	Material int32
+}
+
+// MeshTriangle returns a flat MeshFace between the vertices with the
+// provided indices that has no texture coordinates and uses the material
+// of the mesh.
+func MeshTriangle(v1, v2, v3 int) MeshFace {
+	return MeshFace{
+		Vertices:  [3]int32{int32(v1), int32(v2), int32(v3)},
+		Normals:   [3]int32{-1, -1, -1},
+		TexCoords: [3]int32{-1, -1, -1},
+		Material:  -1,
+	}
+}
+
+// Mesh creates an indexed triangle mesh from shared vertex, normal, and
+// texture coordinate (stored in X and Y) arrays. Each of the provided
+// materials can be assigned to faces via MeshFace.Material.
+// A bounding volume hierarchy is built over the faces so that rays only
+// test the faces near them.
+// It implements the Object interface.
+func Mesh(vertices, normals, texCoords []Tuple, faces []MeshFace, materials ...MaterialT) *MeshT {
+	m := &MeshT{
+		Shape:     Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Vertices:  vertices,
+		Normals:   normals,
+		TexCoords: texCoords,
+		Faces:     append([]MeshFace(nil), faces...),
+	}
+
+	for i, f := range m.Faces {
+		for j := 0; j < 3; j++ {
+			if f.Vertices[j] < 0 || int(f.Vertices[j]) >= len(vertices) {
+				log.Fatalf("programming error - mesh face %v vertex index %v out of range", i, f.Vertices[j])
+			}
+			if f.Normals[j] >= 0 && int(f.Normals[j]) >= len(normals) {
+				log.Fatalf("programming error - mesh face %v normal index %v out of range", i, f.Normals[j])
+			}
+			if f.TexCoords[j] >= 0 && int(f.TexCoords[j]) >= len(texCoords) {
+				log.Fatalf("programming error - mesh face %v texture coordinate index %v out of range", i, f.TexCoords[j])
+			}
+		}
+		if int(f.Material) >= len(materials) {
+			log.Fatalf("programming error - mesh face %v material index %v out of range", i, f.Material)
+		}
+	}
+
+	for i, material := range materials {
+		m.parts = append(m.parts, &meshPartT{
+			Shape: Shape{Transform: M4Identity(), Material: material, Parent: m},
+			mesh:  m,
+			index: i,
+		})
+	}
+
+	m.buildBVH()
+	return m
+}
+
+// MeshT represents an indexed triangle mesh.
+type MeshT struct {
+	Shape
+	Vertices  []Tuple
+	Normals   []Tuple
+	TexCoords []Tuple
+	Faces     []MeshFace
+
+	parts []*meshPartT
+	nodes []meshNode
+
+	// The cumulative distribution of the face areas is only built when
+	// the mesh is first sampled as a geometry light.
+	areaOnce sync.Once
+	areaCDF  []float64
+	area     float64
+}
+
+var _ Object = &MeshT{}
+
+// meshNode is a node of the bounding volume hierarchy of a mesh.
+// Interior nodes refer to their two children while leaves refer to a
+// range of faces.
+type meshNode struct {
+	bounds      BoundsT
+	left, right int32
+	start, end  int32
+}
+
+// meshPartT represents the faces of a mesh that share a per-face material.
+// Intersections with those faces refer to the part so that the rest of
+// the ray tracer finds their material.
+type meshPartT struct {
+	Shape
+	mesh  *MeshT
+	index int
+}
+
+var _ Object = &meshPartT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (m *MeshT) SetTransform(t M4) Object {
+	m.Transform = t
+	return m
+}
+
+// SetMaterial sets the object's material.
+func (m *MeshT) SetMaterial(material MaterialT) Object {
+	m.setMaterial(material)
+	return m
+}
+
+// SetParent sets the object's parent object.
+func (m *MeshT) SetParent(parent Object) Object {
+	m.Parent = parent
+	return m
+}
+
+// FaceMaterial returns the per-face material with the provided index.
+func (m *MeshT) FaceMaterial(index int) *MaterialT {
+	return m.parts[index].GetMaterial()
+}
+
+// NumFaceMaterials returns the number of per-face materials of the mesh.
+func (m *MeshT) NumFaceMaterials() int {
+	return len(m.parts)
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (m *MeshT) Bounds() *BoundsT {
+	if len(m.nodes) == 0 {
+		return Bounds()
+	}
+	b := m.nodes[0].bounds
+	return &b
+}
+
+// faceBounds returns the bounding box of the face with the provided index.
+func (m *MeshT) faceBounds(index int) BoundsT {
+	b := *Bounds()
+	for _, v := range m.Faces[index].Vertices {
+		b.UpdateBounds(m.Vertices[v])
+	}
+	return b
+}
+
+// buildBVH builds the bounding volume hierarchy, reordering the faces so
+// that each leaf refers to a contiguous range.
+func (m *MeshT) buildBVH() {
+	m.nodes = nil
+	if len(m.Faces) == 0 {
+		return
+	}
+
+	centroids := make([]Tuple, len(m.Faces))
+	for i, f := range m.Faces {
+		p1, p2, p3 := m.Vertices[f.Vertices[0]], m.Vertices[f.Vertices[1]], m.Vertices[f.Vertices[2]]
+		centroids[i] = p1.Add(p2).Add(p3).DivScalar(3)
+	}
+	m.buildNode(centroids, 0, len(m.Faces))
+}
+
+// buildNode appends the node for the faces in [start,end) along with its
+// descendants and returns its index.
+func (m *MeshT) buildNode(centroids []Tuple, start, end int) int32 {
+	index := int32(len(m.nodes))
+	m.nodes = append(m.nodes, meshNode{left: -1, right: -1, start: int32(start), end: int32(end)})
+
+	bounds := *Bounds()
+	centroidBounds := *Bounds()
+	for i := start; i < end; i++ {
+		fb := m.faceBounds(i)
+		bounds.UpdateBounds(fb.Min)
+		bounds.UpdateBounds(fb.Max)
+		centroidBounds.UpdateBounds(centroids[i])
+	}
+	m.nodes[index].bounds = bounds
+
+	if end-start <= meshLeafSize {
+		return index
+	}
+
+	// Split at the median centroid along the longest axis.
+	axis := 0
+	extent := centroidBounds.Max.Sub(centroidBounds.Min)
+	if extent.Y() > extent[axis] {
+		axis = 1
+	}
+	if extent.Z() > extent[axis] {
+		axis = 2
+	}
+	sort.Sort(&meshFaceSorter{faces: m.Faces[start:end], centroids: centroids[start:end], axis: axis})
+
+	mid := (start + end) / 2
+	left := m.buildNode(centroids, start, mid)
+	right := m.buildNode(centroids, mid, end)
+	m.nodes[index].left, m.nodes[index].right = left, right
+	return index
+}
+
+// meshFaceSorter sorts faces (and their centroids) along an axis.
+type meshFaceSorter struct {
+	faces     []MeshFace
+	centroids []Tuple
+	axis      int
+}
+
+func (s *meshFaceSorter) Len() int { return len(s.faces) }
+func (s *meshFaceSorter) Less(i, j int) bool {
+	return s.centroids[i][s.axis] < s.centroids[j][s.axis]
+}
+func (s *meshFaceSorter) Swap(i, j int) {
+	s.faces[i], s.faces[j] = s.faces[j], s.faces[i]
+	s.centroids[i], s.centroids[j] = s.centroids[j], s.centroids[i]
+}
+
+// hitsBounds reports whether the ray intersects the bounding box.
+func hitsBounds(ray RayT, b *BoundsT) bool {
+	xtmin, xtmax := checkAxis(ray.Origin.X(), ray.Direction.X(), b.Min.X(), b.Max.X())
+	ytmin, ytmax := checkAxis(ray.Origin.Y(), ray.Direction.Y(), b.Min.Y(), b.Max.Y())
+	ztmin, ztmax := checkAxis(ray.Origin.Z(), ray.Direction.Z(), b.Min.Z(), b.Max.Z())
+	return math.Max(xtmin, math.Max(ytmin, ztmin)) <= math.Min(xtmax, math.Min(ytmax, ztmax))
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+func (m *MeshT) LocalIntersect(ray RayT) []IntersectionT {
+	if len(m.nodes) == 0 {
+		return nil
+	}
+
+	var xs []IntersectionT
+	stack := []int32{0}
+	for len(stack) > 0 {
+		node := &m.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !hitsBounds(ray, &node.bounds) {
+			continue
+		}
+		if node.left >= 0 {
+			stack = append(stack, node.left, node.right)
+			continue
+		}
+
+		for i := node.start; i < node.end; i++ {
+			f := &m.Faces[i]
+			t, u, v, ok := intersectTriangle(ray, m.Vertices[f.Vertices[0]], m.Vertices[f.Vertices[1]], m.Vertices[f.Vertices[2]])
+			if !ok {
+				continue
+			}
+			var object Object = m
+			if f.Material >= 0 {
+				object = m.parts[f.Material]
+			}
+			xs = append(xs, IntersectionT{T: t, Object: object, U: u, V: v, Face: int(i)})
+		}
+	}
+
+	return Intersections(xs...)
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+// Smooth faces interpolate their vertex normals while flat faces use the
+// normal of the triangle.
+func (m *MeshT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	f := &m.Faces[hit.Face]
+	if f.Normals[0] >= 0 {
+		n1, n2, n3 := m.Normals[f.Normals[0]], m.Normals[f.Normals[1]], m.Normals[f.Normals[2]]
+		return n2.MultScalar(hit.U).Add(n3.MultScalar(hit.V)).Add(n1.MultScalar(1 - hit.U - hit.V))
+	}
+
+	p1 := m.Vertices[f.Vertices[0]]
+	e1 := m.Vertices[f.Vertices[1]].Sub(p1)
+	e2 := m.Vertices[f.Vertices[2]].Sub(p1)
+	return e2.Cross(e1).Normalize()
+}
+
+// UVAt returns the interpolated texture coordinates of the mesh at the
+// intersection, or (hit.U, hit.V) if the face has no texture coordinates.
+func (m *MeshT) UVAt(hit *IntersectionT) (float64, float64) {
+	f := &m.Faces[hit.Face]
+	if f.TexCoords[0] < 0 {
+		return hit.U, hit.V
+	}
+	t1, t2, t3 := m.TexCoords[f.TexCoords[0]], m.TexCoords[f.TexCoords[1]], m.TexCoords[f.TexCoords[2]]
+	uv := t2.MultScalar(hit.U).Add(t3.MultScalar(hit.V)).Add(t1.MultScalar(1 - hit.U - hit.V))
+	return uv.X(), uv.Y()
+}
+
+// faceAreas returns the cumulative distribution of the areas of the faces
+// along with their total.
+func (m *MeshT) faceAreas() ([]float64, float64) {
+	m.areaOnce.Do(func() {
+		areas := make([]float64, len(m.Faces))
+		for i := range m.Faces {
+			f := &m.Faces[i]
+			p1 := m.Vertices[f.Vertices[0]]
+			e1 := m.Vertices[f.Vertices[1]].Sub(p1)
+			e2 := m.Vertices[f.Vertices[2]].Sub(p1)
+			areas[i] = e1.Cross(e2).Magnitude() / 2
+		}
+		m.areaCDF, m.area = cumulative(areas)
+	})
+	return m.areaCDF, m.area
+}
+
+// SampleSurface maps the provided (u,v) values uniformly to a point on
+// the mesh. u selects the face, in proportion to its area, as well as the
+// position on it. Faces with a per-face material return their mesh part
+// so that its Emission is used.
+func (m *MeshT) SampleSurface(u, v float64) (Tuple, Object) {
+	cdf, area := m.faceAreas()
+	if area <= 0 {
+		log.Fatalf("programming error - mesh has no area to sample")
+	}
+
+	i, u, _ := sampleCumulative(cdf, u)
+	f := &m.Faces[i]
+	p1 := m.Vertices[f.Vertices[0]]
+	e1 := m.Vertices[f.Vertices[1]].Sub(p1)
+	e2 := m.Vertices[f.Vertices[2]].Sub(p1)
+
+	su := math.Sqrt(u)
+	point := p1.Add(e1.MultScalar(su * (1 - v))).Add(e2.MultScalar(su * v))
+	if f.Material >= 0 {
+		return point, m.parts[f.Material]
+	}
+	return point, m
+}
+
+// SurfaceArea returns the total area of the faces of the mesh.
+func (m *MeshT) SurfaceArea() float64 {
+	_, area := m.faceAreas()
+	return area
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (m *MeshT) Includes(other Object) bool {
+	if m == other {
+		return true
+	}
+	for _, p := range m.parts {
+		if p == other {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTransform is not supported for mesh parts.
+func (p *meshPartT) SetTransform(m M4) Object {
+	log.Fatalf("programming error - mesh parts cannot be transformed")
+	return p
+}
+
+// SetMaterial sets the object's material.
+func (p *meshPartT) SetMaterial(material MaterialT) Object {
+	p.setMaterial(material)
+	return p
+}
+
+// SetParent is not supported for mesh parts.
+func (p *meshPartT) SetParent(parent Object) Object {
+	log.Fatalf("programming error - mesh parts cannot be reparented")
+	return p
+}
+
+// Bounds returns the bounds of the whole mesh.
+func (p *meshPartT) Bounds() *BoundsT {
+	return p.mesh.Bounds()
+}
+
+// LocalIntersect returns nil since the mesh intersects its own faces.
+func (p *meshPartT) LocalIntersect(ray RayT) []IntersectionT {
+	return nil
+}
+
+// LocalNormalAt returns the normal vector of the mesh at the intersection.
+func (p *meshPartT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	return p.mesh.LocalNormalAt(objectPoint, hit)
+}
+
+// Includes returns whether this object is the other object.
+func (p *meshPartT) Includes(other Object) bool {
+	return p == other
+}
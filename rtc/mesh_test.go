@@ -0,0 +1,236 @@
+package rtc
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// gridMesh returns a mesh of n*n unit squares (2 triangles each) covering
+// the X-Z plane from (0,0,0) to (n,0,n) with a bump in the Y direction.
+func gridMesh(n int) *MeshT {
+	var vertices []Tuple
+	for z := 0; z <= n; z++ {
+		for x := 0; x <= n; x++ {
+			vertices = append(vertices, Point(float64(x), math.Sin(float64(x+z)), float64(z)))
+		}
+	}
+	var faces []MeshFace
+	for z := 0; z < n; z++ {
+		for x := 0; x < n; x++ {
+			v00 := z*(n+1) + x
+			v10, v01, v11 := v00+1, v00+n+1, v00+n+2
+			faces = append(faces, MeshTriangle(v00, v10, v11), MeshTriangle(v00, v11, v01))
+		}
+	}
+	return Mesh(vertices, nil, nil, faces)
+}
+
+func TestMeshT_LocalIntersect(t *testing.T) {
+	vertices := []Tuple{Point(-1, 1, 0), Point(-1, 0, 0), Point(1, 0, 0), Point(1, 1, 0)}
+	m := Mesh(vertices, nil, nil, []MeshFace{MeshTriangle(0, 1, 2), MeshTriangle(0, 2, 3)})
+
+	t1 := Triangle(vertices[0], vertices[1], vertices[2])
+	t2 := Triangle(vertices[0], vertices[2], vertices[3])
+
+	tests := []struct {
+		name     string
+		ray      RayT
+		want     *TriangleT
+		wantFace int
+	}{
+		{name: "first face", ray: Ray(Point(-0.5, 0.25, -2), Vector(0, 0, 1)), want: t1, wantFace: 0},
+		{name: "second face", ray: Ray(Point(0.5, 0.75, -2), Vector(0, 0, 1)), want: t2, wantFace: 1},
+		{name: "miss", ray: Ray(Point(2, 0.5, -2), Vector(0, 0, 1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.LocalIntersect(tt.ray)
+			if tt.want == nil {
+				if len(got) != 0 {
+					t.Errorf("LocalIntersect = %v, want none", got)
+				}
+				return
+			}
+
+			want := tt.want.LocalIntersect(tt.ray)
+			if len(got) != 1 || len(want) != 1 {
+				t.Fatalf("LocalIntersect = %v, want %v", got, want)
+			}
+			if got[0].Object != m {
+				t.Errorf("Object = %v, want %v", got[0].Object, m)
+			}
+			if got[0].Face != tt.wantFace {
+				t.Errorf("Face = %v, want %v", got[0].Face, tt.wantFace)
+			}
+			if math.Abs(got[0].T-want[0].T) > epsilon || math.Abs(got[0].U-want[0].U) > epsilon || math.Abs(got[0].V-want[0].V) > epsilon {
+				t.Errorf("LocalIntersect = %+v, want %+v", got[0], want[0])
+			}
+			if n, want := m.LocalNormalAt(tt.ray.Position(got[0].T), &got[0]), tt.want.Normal; !n.Equal(want) {
+				t.Errorf("LocalNormalAt = %v, want %v", n, want)
+			}
+		})
+	}
+}
+
+func TestMeshT_LocalIntersect_BVH(t *testing.T) {
+	const n = 20
+	m := gridMesh(n)
+
+	// Compare the BVH against testing every face.
+	bruteForce := func(ray RayT) []float64 {
+		var ts []float64
+		for _, f := range m.Faces {
+			if t, _, _, ok := intersectTriangle(ray, m.Vertices[f.Vertices[0]], m.Vertices[f.Vertices[1]], m.Vertices[f.Vertices[2]]); ok {
+				ts = append(ts, t)
+			}
+		}
+		xs := make([]IntersectionT, len(ts))
+		for i, t := range ts {
+			xs[i] = Intersection(t, m)
+		}
+		xs = Intersections(xs...)
+		for i := range xs {
+			ts[i] = xs[i].T
+		}
+		return ts
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		origin := Point(r.Float64()*n, 5, r.Float64()*n)
+		direction := Vector(r.Float64()-0.5, -1, r.Float64()-0.5)
+		ray := Ray(origin, direction)
+
+		got := m.LocalIntersect(ray)
+		want := bruteForce(ray)
+		if len(got) != len(want) {
+			t.Fatalf("ray %v: LocalIntersect = %v, want Ts %v", ray, got, want)
+		}
+		for j := range got {
+			if math.Abs(got[j].T-want[j]) > epsilon {
+				t.Errorf("ray %v: LocalIntersect[%v].T = %v, want %v", ray, j, got[j].T, want[j])
+			}
+		}
+	}
+
+	if got, want := m.Bounds().Max.X(), float64(n); got != want {
+		t.Errorf("Bounds.Max.X = %v, want %v", got, want)
+	}
+}
+
+func TestMeshT_SmoothNormalsAndUVs(t *testing.T) {
+	vertices := []Tuple{Point(0, 1, 0), Point(-1, 0, 0), Point(1, 0, 0)}
+	normals := []Tuple{Vector(0, 1, 0), Vector(-1, 0, 0), Vector(1, 0, 0)}
+	texCoords := []Tuple{Point(0.5, 1, 0), Point(0, 0, 0), Point(1, 0, 0)}
+	face := MeshTriangle(0, 1, 2)
+	face.Normals = [3]int32{0, 1, 2}
+	face.TexCoords = [3]int32{0, 1, 2}
+	m := Mesh(vertices, normals, texCoords, []MeshFace{face})
+
+	hit := IntersectionT{T: 1, Object: m, U: 0.45, V: 0.25, Face: 0}
+	want := SmoothTriangle(vertices[0], vertices[1], vertices[2], normals[0], normals[1], normals[2])
+	if got, want := m.LocalNormalAt(Point(0, 0, 0), &hit), want.LocalNormalAt(Point(0, 0, 0), &hit); !got.Equal(want) {
+		t.Errorf("LocalNormalAt = %v, want %v", got, want)
+	}
+
+	u, v := m.UVAt(&hit)
+	if wantU, wantV := 0.3*0.5+0.25, 0.3; math.Abs(u-wantU) > epsilon || math.Abs(v-wantV) > epsilon {
+		t.Errorf("UVAt = (%v,%v), want (%v,%v)", u, v, wantU, wantV)
+	}
+}
+
+func TestMeshT_FaceMaterials(t *testing.T) {
+	vertices := []Tuple{Point(-1, 1, 0), Point(-1, 0, 0), Point(1, 0, 0), Point(1, 1, 0)}
+	red := GetMaterial()
+	red.Color = Color(1, 0, 0)
+	f1, f2 := MeshTriangle(0, 1, 2), MeshTriangle(0, 2, 3)
+	f2.Material = 0
+	m := Mesh(vertices, nil, nil, []MeshFace{f1, f2}, red)
+	m.SetTransform(Translation(0, 0, 5))
+	m.GetMaterial().Color = Color(0, 0, 1)
+
+	if got, want := m.NumFaceMaterials(), 1; got != want {
+		t.Fatalf("NumFaceMaterials = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name      string
+		ray       RayT
+		wantColor Tuple
+	}{
+		{name: "mesh material", ray: Ray(Point(-0.5, 0.25, 0), Vector(0, 0, 1)), wantColor: Color(0, 0, 1)},
+		{name: "face material", ray: Ray(Point(0.5, 0.75, 0), Vector(0, 0, 1)), wantColor: Color(1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xs := Intersect(m, tt.ray)
+			if len(xs) != 1 {
+				t.Fatalf("Intersect = %v, want 1 intersection", xs)
+			}
+			if !m.Includes(xs[0].Object) {
+				t.Errorf("Includes(%v) = false, want true", xs[0].Object)
+			}
+			if got := xs[0].Object.GetMaterial().Color; !got.Equal(tt.wantColor) {
+				t.Errorf("material color = %v, want %v", got, tt.wantColor)
+			}
+
+			comps := xs[0].PrepareComputations(tt.ray, xs)
+			if got, want := comps.Point, Point(tt.ray.Origin.X(), tt.ray.Origin.Y(), 5); !got.Equal(want) {
+				t.Errorf("comps.Point = %v, want %v", got, want)
+			}
+			if got, want := comps.NormalVector, Vector(0, 0, -1); !got.Equal(want) {
+				t.Errorf("comps.NormalVector = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMeshT_SampleSurface(t *testing.T) {
+	// A small face with the mesh material and a face with three times its
+	// area and an emissive per-face material.
+	vertices := []Tuple{
+		Point(0, 0, 0), Point(2, 0, 0), Point(0, 0, 1),
+		Point(0, 1, 0), Point(3, 1, 0), Point(0, 1, 2),
+	}
+	lamp := GetMaterial()
+	lamp.Emission = Color(1, 1, 1)
+	f1, f2 := MeshTriangle(0, 1, 2), MeshTriangle(3, 4, 5)
+	f2.Material = 0
+	m := Mesh(vertices, nil, nil, []MeshFace{f1, f2}, lamp)
+	m.SetTransform(Translation(0, 5, 0))
+
+	if got, want := m.SurfaceArea(), 4.0; math.Abs(got-want) > epsilon {
+		t.Errorf("SurfaceArea = %v, want %v", got, want)
+	}
+
+	const n = 100
+	var onLamp int
+	for i := 0; i < n; i++ {
+		for _, v := range []float64{0, 0.5, 1} {
+			p, object := m.SampleSurface((float64(i)+0.5)/n, v)
+			world := ObjectToWorld(object, p)
+			switch {
+			case object == m && math.Abs(world.Y()-5) < epsilon && p.X()/2+p.Z() <= 1+epsilon:
+			case object == m.parts[0] && math.Abs(world.Y()-6) < epsilon && p.X()/3+p.Z()/2 <= 1+epsilon:
+				onLamp++
+			default:
+				t.Fatalf("SampleSurface = (%v, %v), not on the face of the object", p, object)
+			}
+		}
+	}
+	if got, want := onLamp, 3*n*3/4; got != want {
+		t.Errorf("samples on the larger face = %v, want %v", got, want)
+	}
+
+	light := GeometryLight(m, 4, 1)
+	var total Tuple
+	for _, s := range light.samples() {
+		total = total.Add(s.intensity)
+	}
+	if want := Color(0.75, 0.75, 0.75); !total.Equal(want) {
+		t.Errorf("total light intensity = %v, want %v", total, want)
+	}
+}
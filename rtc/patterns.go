@@ -111,3 +111,29 @@ func (s *CheckersPatternT) LocalPatternAt(localPoint Tuple) Tuple {
 	}
 	return s.b
 }
+
+// NoisePatternT is a pattern that blends between two colors with
+// Perlin noise. It is well suited to bump mapping (see PatternBump).
+// It implements the Pattern interface.
+type NoisePatternT struct {
+	BasePattern
+	a Tuple
+	b Tuple
+}
+
+var _ Pattern = &NoisePatternT{}
+
+// NoisePattern returns a NoisePatternT.
+func NoisePattern(a, b Tuple) *NoisePatternT {
+	return &NoisePatternT{
+		BasePattern: BasePattern{transform: M4Identity()},
+		a:           a,
+		b:           b,
+	}
+}
+
+// LocalPatternAt returns a color at a local point.
+func (s *NoisePatternT) LocalPatternAt(localPoint Tuple) Tuple {
+	t := (PerlinNoise(localPoint.X(), localPoint.Y(), localPoint.Z()) + 1) / 2
+	return s.a.MultScalar(1 - t).Add(s.b.MultScalar(t))
+}
@@ -284,3 +284,40 @@ func TestCheckersPatternT_LocalPatternAt(t *testing.T) {
 		})
 	}
 }
+
+func TestNoisePatternT_LocalPatternAt(t *testing.T) {
+	black := Color(0, 0, 0)
+	white := Color(1, 1, 1)
+
+	pattern := NoisePattern(white, black)
+
+	tests := []struct {
+		name string
+		p    Tuple
+		want Tuple
+	}{
+		{
+			name: "Noise is half way between the colors at lattice points",
+			p:    Point(0, 0, 0),
+			want: Color(0.5, 0.5, 0.5),
+		},
+		{
+			name: "Noise is half way between the colors at lattice points",
+			p:    Point(3, -2, 7),
+			want: Color(0.5, 0.5, 0.5),
+		},
+		{
+			name: "Noise varies between lattice points",
+			p:    Point(0.5, 0.5, 0.5),
+			want: white.MultScalar(1 - (PerlinNoise(0.5, 0.5, 0.5)+1)/2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pattern.LocalPatternAt(tt.p); !got.Equal(tt.want) {
+				t.Errorf("NoisePatternT.LocalPatternAt(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package rtc
+
+// PickT describes the object found along a ray by WorldT.Pick, such as
+// the object under the mouse in a scene editor.
+type PickT struct {
+	// Object is the primitive object that was hit.
+	Object Object
+	// Path holds the ancestry of the object from its top-level object in
+	// the world (such as a group or CSG) down to the object itself.
+	// Objects within geometry shared by an instance are reported as the
+	// shared objects themselves, with the instance as their ancestor.
+	Path []Object
+
+	// Point is the world space point that was hit, and Normal is the
+	// world space unit normal there, facing back along the ray.
+	Point  Tuple
+	Normal Tuple
+	// Inside reports whether the ray hit the object from the inside.
+	Inside bool
+
+	// U and V are the texture coordinates of the hit for objects that
+	// implement the TangentFramer interface, or the intersection's own
+	// U and V values (such as barycentric coordinates) otherwise.
+	U, V float64
+	// Face is the index of the face that was hit for objects made of many
+	// faces, such as meshes.
+	Face int
+
+	Material *MaterialT
+	// Distance is the distance from the origin of the ray to the point.
+	Distance float64
+}
+
+// Pick returns what the ray hits first, or nil if it misses every object.
+// Unlike ColorAt, no shading is done, and the invisible boundaries of
+// participating media can be picked as well.
+func (w *WorldT) Pick(ray RayT) *PickT {
+	xs := w.IntersectWorld(ray)
+	hit := Hit(xs)
+	if hit == nil {
+		return nil
+	}
+
+	comps := hit.PrepareComputations(ray, xs)
+	object := comps.Object
+	if _, ok := unwrapInstanced(object).(*meshPartT); ok {
+		// Faces with a per-face material are reported as part of the mesh.
+		object = object.GetParent()
+	}
+
+	pick := &PickT{
+		Object:   unwrapInstanced(object),
+		Point:    comps.Point,
+		Normal:   comps.NormalVector,
+		Inside:   comps.Inside,
+		U:        hit.U,
+		V:        hit.V,
+		Face:     hit.Face,
+		Material: comps.Object.GetMaterial(),
+		Distance: hit.T * ray.Direction.Magnitude(),
+	}
+
+	for o := object; o != nil; o = o.GetParent() {
+		pick.Path = append([]Object{unwrapInstanced(o)}, pick.Path...)
+	}
+
+	if f, ok := pick.Object.(TangentFramer); ok {
+		pick.U, pick.V, _, _ = f.LocalTangentFrame(WorldToObject(object, comps.Point), hit)
+	}
+
+	return pick
+}
+
+// Pick returns what is seen at the pixel of the camera, or nil if the
+// pixel's ray misses every object.
+func (c *CameraT) Pick(world *WorldT, px, py int) *PickT {
+	return world.Pick(c.RayForPixel(px, py))
+}
@@ -0,0 +1,268 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWorldT_Pick(t *testing.T) {
+	ball := Sphere()
+	ball.GetMaterial().Color = Color(1, 0, 0)
+	box := Cube()
+	box.SetTransform(Translation(3, 0, 0))
+	csg := CSG(CSGUnion, ball, box)
+	group := Group(csg)
+	group.SetTransform(Translation(0, 1, 0))
+
+	floor := Plane()
+	fog := Cube()
+	fog.SetTransform(Translation(-5, 1, 0))
+	fog.GetMaterial().Volume = true
+
+	// A unit square with texture coordinates scaled by 4, where the upper
+	// left face uses a per-face material.
+	square := []Tuple{Point(0, 0, 0), Point(1, 0, 0), Point(1, 1, 0), Point(0, 1, 0)}
+	var texCoords []Tuple
+	for _, p := range square {
+		texCoords = append(texCoords, Point(4*p.X(), 4*p.Y(), 0))
+	}
+	lower, upper := MeshTriangle(0, 1, 2), MeshTriangle(0, 2, 3)
+	lower.TexCoords = [3]int32{0, 1, 2}
+	upper.TexCoords = [3]int32{0, 2, 3}
+	upper.Material = 0
+	mesh := Mesh(square, nil, texCoords, []MeshFace{lower, upper}, GetMaterial())
+	mesh.SetTransform(Translation(10, 0, 10))
+
+	w := World()
+	w.Objects = []Object{group, floor, fog, mesh}
+
+	tests := []struct {
+		name         string
+		ray          RayT
+		wantObject   Object
+		wantMaterial *MaterialT
+		wantPath     []Object
+		wantPoint    Tuple
+		wantNormal   Tuple
+		wantU, wantV float64
+		wantDistance float64
+		wantInside   bool
+	}{
+		{
+			name:         "sphere in a CSG in a group",
+			ray:          Ray(Point(0, 1, -5), Vector(0, 0, 1)),
+			wantObject:   ball,
+			wantPath:     []Object{group, csg, ball},
+			wantPoint:    Point(0, 1, -1),
+			wantNormal:   Vector(0, 0, -1),
+			wantU:        0,
+			wantV:        0.5,
+			wantDistance: 4,
+		},
+		{
+			name:         "cube face",
+			ray:          Ray(Point(3.5, 1.5, -5), Vector(0, 0, 2)),
+			wantObject:   box,
+			wantPath:     []Object{group, csg, box},
+			wantPoint:    Point(3.5, 1.5, -1),
+			wantNormal:   Vector(0, 0, -1),
+			wantU:        0.25,
+			wantV:        0.75,
+			wantDistance: 4,
+		},
+		{
+			name:         "floor",
+			ray:          Ray(Point(0.25, 5, 10.5), Vector(0, -1, 0)),
+			wantObject:   floor,
+			wantPath:     []Object{floor},
+			wantPoint:    Point(0.25, 0, 10.5),
+			wantNormal:   Vector(0, 1, 0),
+			wantU:        0.25,
+			wantV:        0.5,
+			wantDistance: 5,
+		},
+		{
+			name:         "inside the sphere",
+			ray:          Ray(Point(0, 1, 0), Vector(0, 1, 0)),
+			wantObject:   ball,
+			wantPath:     []Object{group, csg, ball},
+			wantPoint:    Point(0, 2, 0),
+			wantNormal:   Vector(0, -1, 0),
+			wantU:        0.5,
+			wantV:        1,
+			wantDistance: 1,
+			wantInside:   true,
+		},
+		{
+			name:         "volume boundary",
+			ray:          Ray(Point(-5, 1, -5), Vector(0, 0, 1)),
+			wantObject:   fog,
+			wantPath:     []Object{fog},
+			wantPoint:    Point(-5, 1, -1),
+			wantNormal:   Vector(0, 0, -1),
+			wantU:        0.5,
+			wantV:        0.5,
+			wantDistance: 4,
+		},
+		{
+			name:         "mesh face",
+			ray:          Ray(Point(10.75, 0.25, 5), Vector(0, 0, 1)),
+			wantObject:   mesh,
+			wantPath:     []Object{mesh},
+			wantPoint:    Point(10.75, 0.25, 10),
+			wantNormal:   Vector(0, 0, -1),
+			wantU:        3,
+			wantV:        1,
+			wantDistance: 5,
+		},
+		{
+			name:         "mesh face with a per-face material",
+			ray:          Ray(Point(10.25, 0.75, 5), Vector(0, 0, 1)),
+			wantObject:   mesh,
+			wantMaterial: mesh.FaceMaterial(0),
+			wantPath:     []Object{mesh},
+			wantPoint:    Point(10.25, 0.75, 10),
+			wantNormal:   Vector(0, 0, -1),
+			wantU:        1,
+			wantV:        3,
+			wantDistance: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := w.Pick(tt.ray)
+			if got == nil {
+				t.Fatal("Pick = nil, want a hit")
+			}
+			if got.Object != tt.wantObject {
+				t.Errorf("Object = %v, want %v", got.Object, tt.wantObject)
+			}
+			if len(got.Path) != len(tt.wantPath) {
+				t.Fatalf("Path = %v, want %v", got.Path, tt.wantPath)
+			}
+			for i := range tt.wantPath {
+				if got.Path[i] != tt.wantPath[i] {
+					t.Errorf("Path[%v] = %v, want %v", i, got.Path[i], tt.wantPath[i])
+				}
+			}
+			if !got.Point.Equal(tt.wantPoint) {
+				t.Errorf("Point = %v, want %v", got.Point, tt.wantPoint)
+			}
+			if !got.Normal.Equal(tt.wantNormal) {
+				t.Errorf("Normal = %v, want %v", got.Normal, tt.wantNormal)
+			}
+			if math.Abs(got.U-tt.wantU) > epsilon || math.Abs(got.V-tt.wantV) > epsilon {
+				t.Errorf("UV = (%v,%v), want (%v,%v)", got.U, got.V, tt.wantU, tt.wantV)
+			}
+			if math.Abs(got.Distance-tt.wantDistance) > epsilon {
+				t.Errorf("Distance = %v, want %v", got.Distance, tt.wantDistance)
+			}
+			if got.Inside != tt.wantInside {
+				t.Errorf("Inside = %v, want %v", got.Inside, tt.wantInside)
+			}
+			wantMaterial := tt.wantMaterial
+			if wantMaterial == nil {
+				wantMaterial = tt.wantObject.GetMaterial()
+			}
+			if got.Material != wantMaterial {
+				t.Errorf("Material = %v, want %v", got.Material, wantMaterial)
+			}
+		})
+	}
+
+	if got := w.Pick(Ray(Point(0, 5, 0), Vector(0, 1, 0))); got != nil {
+		t.Errorf("Pick of a miss = %+v, want nil", got)
+	}
+}
+
+func TestCameraT_Pick(t *testing.T) {
+	w := DefaultWorld()
+	c := Camera(11, 11, math.Pi/2)
+	c.Transform = ViewTransform(Point(0, 0, -5), Point(0, 0, 0), Vector(0, 1, 0))
+
+	got := c.Pick(w, 5, 5)
+	if got == nil {
+		t.Fatal("Pick(5,5) = nil, want the outer sphere")
+	}
+	if got.Object != w.Objects[0] {
+		t.Errorf("Object = %v, want %v", got.Object, w.Objects[0])
+	}
+	if !got.Point.Equal(Point(0, 0, -1)) {
+		t.Errorf("Point = %v, want %v", got.Point, Point(0, 0, -1))
+	}
+
+	if got := c.Pick(w, 0, 0); got != nil {
+		t.Errorf("Pick(0,0) = %+v, want nil", got)
+	}
+}
+
+func TestWorldT_Pick_Instance(t *testing.T) {
+	ball := Sphere()
+	shared := Group(ball)
+	ballInstance := Instance(shared)
+	ballInstance.SetTransform(Translation(0, 0, 5))
+
+	// A unit square whose upper left face uses a per-face material.
+	square := []Tuple{Point(0, 0, 0), Point(1, 0, 0), Point(1, 1, 0), Point(0, 1, 0)}
+	upper := MeshTriangle(0, 2, 3)
+	upper.Material = 0
+	mesh := Mesh(square, nil, nil, []MeshFace{MeshTriangle(0, 1, 2), upper}, GetMaterial())
+	meshInstance := Instance(mesh)
+	meshInstance.SetTransform(Translation(10, 0, 5))
+
+	w := World()
+	w.Objects = []Object{ballInstance, meshInstance}
+
+	tests := []struct {
+		name       string
+		ray        RayT
+		wantObject Object
+		wantPath   []Object
+		wantPoint  Tuple
+	}{
+		{
+			name:       "sphere in a group shared by an instance",
+			ray:        Ray(Point(0, 0, 0), Vector(0, 0, 1)),
+			wantObject: ball,
+			wantPath:   []Object{ballInstance, shared, ball},
+			wantPoint:  Point(0, 0, 4),
+		},
+		{
+			name:       "mesh face with a per-face material shared by an instance",
+			ray:        Ray(Point(10.25, 0.75, 0), Vector(0, 0, 1)),
+			wantObject: mesh,
+			wantPath:   []Object{meshInstance, mesh},
+			wantPoint:  Point(10.25, 0.75, 5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := w.Pick(tt.ray)
+			if got == nil {
+				t.Fatal("Pick = nil, want a hit")
+			}
+			if got.Object != tt.wantObject {
+				t.Errorf("Object = %v, want %v", got.Object, tt.wantObject)
+			}
+			if len(got.Path) != len(tt.wantPath) {
+				t.Fatalf("Path = %v, want %v", got.Path, tt.wantPath)
+			}
+			for i := range tt.wantPath {
+				if got.Path[i] != tt.wantPath[i] {
+					t.Errorf("Path[%v] = %v, want %v", i, got.Path[i], tt.wantPath[i])
+				}
+			}
+			if !got.Point.Equal(tt.wantPoint) {
+				t.Errorf("Point = %v, want %v", got.Point, tt.wantPoint)
+			}
+		})
+	}
+
+	// The UV of the sphere is found in the space of the shared geometry.
+	got := w.Pick(tests[0].ray)
+	if got.U != 0 || got.V != 0.5 {
+		t.Errorf("UV = (%v,%v), want (0,0.5)", got.U, got.V)
+	}
+}
@@ -23,7 +23,7 @@ func (p *PlaneT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (p *PlaneT) SetMaterial(material MaterialT) Object {
-	p.Material = material
+	p.setMaterial(material)
 	return p
 }
 
@@ -0,0 +1,107 @@
+package rtc
+
+import "math"
+
+// Quad returns a new QuadT, a parallelogram with one corner at the provided
+// point and edges along the uvec and vvec vectors.
+// The front of the quad (the direction of its normal) is the side from
+// which vvec appears counterclockwise from uvec.
+// It implements the Object interface.
+func Quad(corner, uvec, vvec Tuple) *QuadT {
+	return &QuadT{
+		Shape:  Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Corner: corner,
+		UVec:   uvec,
+		VVec:   vvec,
+	}
+}
+
+// QuadT represents a quad (parallelogram) object.
+type QuadT struct {
+	Shape
+	Corner Tuple
+	UVec   Tuple
+	VVec   Tuple
+}
+
+var _ Object = &QuadT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (q *QuadT) SetTransform(m M4) Object {
+	q.Transform = m
+	return q
+}
+
+// SetMaterial sets the object's material.
+func (q *QuadT) SetMaterial(material MaterialT) Object {
+	q.setMaterial(material)
+	return q
+}
+
+// SetParent sets the object's parent object.
+func (q *QuadT) SetParent(parent Object) Object {
+	q.Parent = parent
+	return q
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (q *QuadT) Bounds() *BoundsT {
+	bounds := Bounds()
+	bounds.UpdateBounds(q.Corner)
+	bounds.UpdateBounds(q.Corner.Add(q.UVec))
+	bounds.UpdateBounds(q.Corner.Add(q.VVec))
+	bounds.UpdateBounds(q.Corner.Add(q.UVec).Add(q.VVec))
+	return bounds
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+// The U and V values are the relative positions along UVec and VVec.
+func (q *QuadT) LocalIntersect(ray RayT) []IntersectionT {
+	n := q.UVec.Cross(q.VVec) // unnormalized normal
+	normal := n.Normalize()
+	denom := ray.Direction.Dot(normal)
+	if math.Abs(denom) < epsilon {
+		return nil
+	}
+
+	t := q.Corner.Sub(ray.Origin).Dot(normal) / denom
+	w := ray.Position(t).Sub(q.Corner)
+
+	nDotN := n.Dot(n)
+	u := n.Dot(w.Cross(q.VVec)) / nDotN
+	if u < 0 || u > 1 {
+		return nil
+	}
+
+	v := n.Dot(q.UVec.Cross(w)) / nDotN
+	if v < 0 || v > 1 {
+		return nil
+	}
+
+	return []IntersectionT{IntersectionWithUV(t, q, u, v)}
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+func (q *QuadT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	return q.UVec.Cross(q.VVec).Normalize()
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (q *QuadT) Includes(other Object) bool {
+	return q == other
+}
+
+// SampleSurface maps the provided (u,v) values uniformly to a point on
+// the quad.
+func (q *QuadT) SampleSurface(u, v float64) (Tuple, Object) {
+	return q.Corner.Add(q.UVec.MultScalar(u)).Add(q.VVec.MultScalar(v)), q
+}
+
+// SurfaceArea returns the area of the quad.
+func (q *QuadT) SurfaceArea() float64 {
+	return q.UVec.Cross(q.VVec).Magnitude()
+}
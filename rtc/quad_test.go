@@ -0,0 +1,119 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuad(t *testing.T) {
+	q := Quad(Point(-1, 0, -1), Vector(2, 0, 0), Vector(0, 0, 2))
+
+	if got, want := q.LocalNormalAt(Point(0, 0, 0), nil), Vector(0, -1, 0); !got.Equal(want) {
+		t.Errorf("Quad.LocalNormalAt = %v, want %v", got, want)
+	}
+
+	b := q.Bounds()
+	if got, want := b.Min, Point(-1, 0, -1); !got.Equal(want) {
+		t.Errorf("Quad.Bounds.Min = %v, want %v", got, want)
+	}
+	if got, want := b.Max, Point(1, 0, 1); !got.Equal(want) {
+		t.Errorf("Quad.Bounds.Max = %v, want %v", got, want)
+	}
+}
+
+func TestQuadT_ChangedAfterConstruction(t *testing.T) {
+	q := Quad(Point(-1, 0, -1), Vector(2, 0, 0), Vector(0, 0, 2))
+	q.Corner = Point(0, 0, 0)
+	q.UVec = Vector(3, 0, 0)
+	q.VVec = Vector(0, 2, 0)
+
+	if got, want := q.LocalNormalAt(Point(0, 0, 0), nil), Vector(0, 0, 1); !got.Equal(want) {
+		t.Errorf("LocalNormalAt = %v, want %v", got, want)
+	}
+	b := q.Bounds()
+	if !b.Min.Equal(Point(0, 0, 0)) || !b.Max.Equal(Point(3, 2, 0)) {
+		t.Errorf("Bounds = %v, want (0,0,0)-(3,2,0)", b)
+	}
+	if got, want := q.SurfaceArea(), 6.0; math.Abs(got-want) > epsilon {
+		t.Errorf("SurfaceArea = %v, want %v", got, want)
+	}
+
+	xs := q.LocalIntersect(Ray(Point(1.5, 1, -2), Vector(0, 0, 1)))
+	if len(xs) != 1 || math.Abs(xs[0].T-2) > epsilon || math.Abs(xs[0].U-0.5) > epsilon || math.Abs(xs[0].V-0.5) > epsilon {
+		t.Errorf("LocalIntersect = %v, want one hit at t=2 with UV (0.5,0.5)", xs)
+	}
+}
+
+func TestQuadT_LocalIntersect(t *testing.T) {
+	q := Quad(Point(0, 0, 0), Vector(2, 0, 0), Vector(1, 1, 0))
+
+	tests := []struct {
+		name  string
+		ray   RayT
+		want  []IntersectionT
+		wantU float64
+		wantV float64
+	}{
+		{
+			name: "A ray parallel to the quad",
+			ray:  Ray(Point(0, 0, -2), Vector(1, 0, 0)),
+		},
+		{
+			name:  "A ray striking the corner",
+			ray:   Ray(Point(0, 0, -2), Vector(0, 0, 1)),
+			want:  []IntersectionT{Intersection(2, q)},
+			wantU: 0,
+			wantV: 0,
+		},
+		{
+			name:  "A ray striking the middle of the parallelogram",
+			ray:   Ray(Point(1.5, 0.5, -2), Vector(0, 0, 1)),
+			want:  []IntersectionT{Intersection(2, q)},
+			wantU: 0.5,
+			wantV: 0.5,
+		},
+		{
+			name: "A ray missing past the slanted edge",
+			ray:  Ray(Point(0.2, 0.5, -2), Vector(0, 0, 1)),
+		},
+		{
+			name: "A ray missing beyond the far corner",
+			ray:  Ray(Point(3.5, 1.5, -2), Vector(0, 0, 1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := q.LocalIntersect(tt.ray)
+			if len(got) != len(tt.want) {
+				t.Fatalf("LocalIntersect = %v, want %v", got, tt.want)
+			}
+			for i, w := range tt.want {
+				if math.Abs(got[i].T-w.T) > epsilon || got[i].Object != w.Object {
+					t.Errorf("LocalIntersect[%v] = %v, want %v", i, got[i], w)
+				}
+				if math.Abs(got[i].U-tt.wantU) > epsilon || math.Abs(got[i].V-tt.wantV) > epsilon {
+					t.Errorf("LocalIntersect[%v] UV = (%v,%v), want (%v,%v)", i, got[i].U, got[i].V, tt.wantU, tt.wantV)
+				}
+			}
+		})
+	}
+}
+
+func TestQuadT_AsGeometryLight(t *testing.T) {
+	q := Quad(Point(-1, 5, -1), Vector(2, 0, 0), Vector(0, 0, 2))
+	q.GetMaterial().Emission = Color(1, 1, 1)
+	light := GeometryLight(q, 2, 2)
+	light.Jitter = false
+
+	samples := light.samples()
+	want := []Tuple{Point(-0.5, 5, -0.5), Point(0.5, 5, -0.5), Point(-0.5, 5, 0.5), Point(0.5, 5, 0.5)}
+	if len(samples) != len(want) {
+		t.Fatalf("len(samples) = %v, want %v", len(samples), len(want))
+	}
+	for i, w := range want {
+		if got := samples[i].position; !got.Equal(w) {
+			t.Errorf("samples[%v].position = %v, want %v", i, got, w)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package rtc
+
+import "math"
+
+// Quadric creates a general quadric surface defined by the 10 coefficients
+// of the implicit equation:
+//
+//	Ax² + By² + Cz² + Dxy + Exz + Fyz + Gx + Hy + Iz + J = 0
+//
+// Points where the left-hand side is negative are considered to be inside
+// the surface (which matters for closed caps and CSG operations).
+// It implements the Object interface.
+func Quadric(a, b, c, d, e, f, g, h, i, j float64) *QuadricT {
+	return &QuadricT{
+		Shape:   Shape{Transform: M4Identity(), Material: GetMaterial()},
+		A:       a,
+		B:       b,
+		C:       c,
+		D:       d,
+		E:       e,
+		F:       f,
+		G:       g,
+		H:       h,
+		I:       i,
+		J:       j,
+		Minimum: math.Inf(-1),
+		Maximum: math.Inf(1),
+		Closed:  false,
+	}
+}
+
+// Paraboloid creates a paraboloid (x² + z² = y) opening upward along the
+// Y axis with its vertex at the origin.
+// It implements the Object interface.
+func Paraboloid() *QuadricT {
+	return Quadric(1, 0, 1, 0, 0, 0, 0, -1, 0, 0)
+}
+
+// HyperboloidOneSheet creates a hyperboloid of one sheet (x² - y² + z² = 1)
+// around the Y axis with a waist of radius 1.
+// It implements the Object interface.
+func HyperboloidOneSheet() *QuadricT {
+	return Quadric(1, -1, 1, 0, 0, 0, 0, 0, 0, -1)
+}
+
+// HyperboloidTwoSheets creates a hyperboloid of two sheets
+// (x² - y² + z² = -1) around the Y axis with vertices at y=±1.
+// It implements the Object interface.
+func HyperboloidTwoSheets() *QuadricT {
+	return Quadric(1, -1, 1, 0, 0, 0, 0, 0, 0, 1)
+}
+
+// Ellipsoid creates an ellipsoid at the origin with the provided radii
+// along the X, Y, and Z axes.
+// It implements the Object interface.
+func Ellipsoid(rx, ry, rz float64) *QuadricT {
+	return Quadric(1/(rx*rx), 1/(ry*ry), 1/(rz*rz), 0, 0, 0, 0, 0, 0, -1)
+}
+
+// QuadricT represents a general quadric surface, optionally clipped
+// along the Y axis between Minimum and Maximum.
+type QuadricT struct {
+	Shape
+	A, B, C, D, E, F, G, H, I, J float64
+
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+var _ Object = &QuadricT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (q *QuadricT) SetTransform(m M4) Object {
+	q.Transform = m
+	return q
+}
+
+// SetMaterial sets the object's material.
+func (q *QuadricT) SetMaterial(material MaterialT) Object {
+	q.setMaterial(material)
+	return q
+}
+
+// SetParent sets the object's parent object.
+func (q *QuadricT) SetParent(parent Object) Object {
+	q.Parent = parent
+	return q
+}
+
+// evaluate returns the value of the implicit equation at the provided point.
+func (q *QuadricT) evaluate(x, y, z float64) float64 {
+	return q.A*x*x + q.B*y*y + q.C*z*z + q.D*x*y + q.E*x*z + q.F*y*z + q.G*x + q.H*y + q.I*z + q.J
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (q *QuadricT) Bounds() *BoundsT {
+	finite := !math.IsInf(q.Minimum, 0) && !math.IsInf(q.Maximum, 0)
+	// Horizontal cross-sections are closed curves (ellipses) only when the
+	// X-Z quadratic form is definite.
+	xzDefinite := 4*q.A*q.C-q.E*q.E > 0
+
+	if !q.isEllipsoid() && !(finite && xzDefinite) {
+		return &BoundsT{
+			Min: Point(math.Inf(-1), q.Minimum, math.Inf(-1)),
+			Max: Point(math.Inf(1), q.Maximum, math.Inf(1)),
+		}
+	}
+
+	b := Bounds()
+	inRange := func(p Tuple) bool {
+		return p.Y() >= q.Minimum-epsilon && p.Y() <= q.Maximum+epsilon
+	}
+
+	// The extremes of the surface along each axis occur either where the
+	// surface normal is parallel to that axis...
+	for axis := 0; axis < 3; axis++ {
+		for _, p := range q.axisExtremes(axis) {
+			if inRange(p) {
+				b.UpdateBounds(p)
+			}
+		}
+	}
+
+	// ...or along the curves where the surface is clipped.
+	for _, y := range []float64{q.Minimum, q.Maximum} {
+		if math.IsInf(y, 0) {
+			continue
+		}
+		for _, p := range q.sectionExtremes(y) {
+			b.UpdateBounds(p)
+		}
+	}
+
+	return b
+}
+
+// isEllipsoid reports whether the quadratic form of the quadric is
+// definite, meaning the surface (if not empty) is bounded.
+func (q *QuadricT) isEllipsoid() bool {
+	a, b, c := q.A, q.B, q.C
+	d, e, f := q.D/2, q.E/2, q.F/2
+	m1 := a
+	m2 := a*b - d*d
+	m3 := a*(b*c-f*f) - d*(d*c-f*e) + e*(d*f-b*e)
+	return (m1 > 0 && m2 > 0 && m3 > 0) || (m1 < 0 && m2 > 0 && m3 < 0)
+}
+
+// quadraticAlong returns the quadratic coefficients of the implicit
+// equation evaluated along the line p(s) = origin + s*direction.
+func (q *QuadricT) quadraticAlong(origin, direction Tuple) (float64, float64, float64) {
+	ox, oy, oz := origin.X(), origin.Y(), origin.Z()
+	dx, dy, dz := direction.X(), direction.Y(), direction.Z()
+
+	a := q.A*dx*dx + q.B*dy*dy + q.C*dz*dz + q.D*dx*dy + q.E*dx*dz + q.F*dy*dz
+	b := 2*(q.A*ox*dx+q.B*oy*dy+q.C*oz*dz) +
+		q.D*(ox*dy+oy*dx) + q.E*(ox*dz+oz*dx) + q.F*(oy*dz+oz*dy) +
+		q.G*dx + q.H*dy + q.I*dz
+	c := q.evaluate(ox, oy, oz)
+	return a, b, c
+}
+
+// solveScaledQuadratic returns the real roots of c2*x^2 + c1*x + c0 = 0
+// like solveQuadratic, after normalizing the coefficients, which scale with
+// the size of the quadric.
+func solveScaledQuadratic(c2, c1, c0 float64) []float64 {
+	scale := math.Max(math.Abs(c2), math.Max(math.Abs(c1), math.Abs(c0)))
+	if scale == 0 {
+		return nil
+	}
+	return solveQuadratic(c2/scale, c1/scale, c0/scale)
+}
+
+// axisExtremes returns the points on the surface where its gradient is
+// parallel to the given axis (0=X, 1=Y, 2=Z).
+func (q *QuadricT) axisExtremes(axis int) []Tuple {
+	// The gradient is M*p + g.
+	m := [3][3]float64{
+		{2 * q.A, q.D, q.E},
+		{q.D, 2 * q.B, q.F},
+		{q.E, q.F, 2 * q.C},
+	}
+	g := [3]float64{q.G, q.H, q.I}
+
+	// The two gradient components perpendicular to the axis must vanish,
+	// which defines a line parameterized by the coordinate along the axis.
+	i, j := (axis+1)%3, (axis+2)%3
+	det := m[i][i]*m[j][j] - m[i][j]*m[j][i]
+	if math.Abs(det) <= eqnEpsilon*(math.Abs(m[i][i]*m[j][j])+math.Abs(m[i][j]*m[j][i])) {
+		return nil
+	}
+
+	solve := func(s float64) Tuple {
+		ri := -(m[i][axis]*s + g[i])
+		rj := -(m[j][axis]*s + g[j])
+		var p Tuple
+		p[axis] = s
+		p[i] = (ri*m[j][j] - m[i][j]*rj) / det
+		p[j] = (m[i][i]*rj - ri*m[j][i]) / det
+		p[3] = 1
+		return p
+	}
+
+	origin := solve(0)
+	direction := solve(1).Sub(origin)
+
+	var result []Tuple
+	for _, s := range solveScaledQuadratic(q.quadraticAlong(origin, direction)) {
+		result = append(result, origin.Add(direction.MultScalar(s)))
+	}
+	return result
+}
+
+// sectionExtremes returns the points with extreme X and Z values on the
+// (elliptical) curve where the surface crosses the plane at height y.
+func (q *QuadricT) sectionExtremes(y float64) []Tuple {
+	var result []Tuple
+
+	// Extreme X: the Z component of the gradient vanishes.
+	if q.C != 0 {
+		origin := Point(0, y, -(q.F*y+q.I)/(2*q.C))
+		direction := Vector(1, 0, -q.E/(2*q.C))
+		for _, s := range solveScaledQuadratic(q.quadraticAlong(origin, direction)) {
+			result = append(result, origin.Add(direction.MultScalar(s)))
+		}
+	}
+
+	// Extreme Z: the X component of the gradient vanishes.
+	if q.A != 0 {
+		origin := Point(-(q.D*y+q.G)/(2*q.A), y, 0)
+		direction := Vector(-q.E/(2*q.A), 0, 1)
+		for _, s := range solveScaledQuadratic(q.quadraticAlong(origin, direction)) {
+			result = append(result, origin.Add(direction.MultScalar(s)))
+		}
+	}
+
+	return result
+}
+
+func (q *QuadricT) checkCap(ray RayT, t float64) bool {
+	p := ray.Position(t)
+	return q.evaluate(p.X(), p.Y(), p.Z()) <= 0
+}
+
+func (q *QuadricT) intersectCaps(ray RayT, xs []IntersectionT) []IntersectionT {
+	if !q.Closed || math.Abs(ray.Direction.Y()) < epsilon {
+		return xs
+	}
+
+	t := (q.Minimum - ray.Origin.Y()) / ray.Direction.Y()
+	if q.checkCap(ray, t) {
+		xs = append(xs, Intersection(t, q))
+	}
+
+	t = (q.Maximum - ray.Origin.Y()) / ray.Direction.Y()
+	if q.checkCap(ray, t) {
+		xs = append(xs, Intersection(t, q))
+	}
+
+	return xs
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+func (q *QuadricT) LocalIntersect(ray RayT) []IntersectionT {
+	a, b, c := q.quadraticAlong(ray.Origin, ray.Direction)
+
+	// The coefficients scale with the size of the quadric, so a is only
+	// treated as zero relative to the others.
+	var ts []float64
+	if math.Abs(a) <= eqnEpsilon*(math.Abs(b)+math.Abs(c)) {
+		if b == 0 {
+			return q.intersectCaps(ray, nil)
+		}
+		ts = []float64{-c / b}
+	} else {
+		discriminant := b*b - 4*a*c
+		if discriminant < 0 {
+			return q.intersectCaps(ray, nil)
+		}
+
+		sr := math.Sqrt(discriminant)
+		t1 := (-b - sr) / (2 * a)
+		t2 := (-b + sr) / (2 * a)
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		ts = []float64{t1, t2}
+	}
+
+	var xs []IntersectionT
+	for _, t := range ts {
+		y := ray.Origin.Y() + t*ray.Direction.Y()
+		if q.Minimum < y && y < q.Maximum {
+			xs = append(xs, Intersection(t, q))
+		}
+	}
+	xs = q.intersectCaps(ray, xs)
+
+	return xs
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+func (q *QuadricT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	x, y, z := objectPoint.X(), objectPoint.Y(), objectPoint.Z()
+	if q.Closed && q.evaluate(x, y, z) < 0 {
+		if y >= q.Maximum-epsilon {
+			return Vector(0, 1, 0)
+		}
+		if y <= q.Minimum+epsilon {
+			return Vector(0, -1, 0)
+		}
+	}
+
+	return Vector(
+		2*q.A*x+q.D*y+q.E*z+q.G,
+		2*q.B*y+q.D*x+q.F*z+q.H,
+		2*q.C*z+q.E*x+q.F*y+q.I,
+	)
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (q *QuadricT) Includes(other Object) bool {
+	return q == other
+}
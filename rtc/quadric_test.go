@@ -0,0 +1,307 @@
+package rtc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestQuadric(t *testing.T) {
+	q := Paraboloid()
+
+	if got, want := q.Minimum, math.Inf(-1); got != want {
+		t.Errorf("Quadric.Minimum = %v, want %v", got, want)
+	}
+
+	if got, want := q.Maximum, math.Inf(1); got != want {
+		t.Errorf("Quadric.Maximum = %v, want %v", got, want)
+	}
+
+	if got, want := q.Closed, false; got != want {
+		t.Errorf("Quadric.Closed = %v, want %v", got, want)
+	}
+}
+
+func TestQuadricT_LocalIntersect(t *testing.T) {
+	sq2 := math.Sqrt2
+
+	tests := []struct {
+		name   string
+		object *QuadricT
+		ray    RayT
+		want   []float64
+	}{
+		{
+			name:   "A ray hits a paraboloid twice",
+			object: Paraboloid(),
+			ray:    Ray(Point(-5, 1, 0), Vector(1, 0, 0)),
+			want:   []float64{4, 6},
+		},
+		{
+			name:   "A ray down the axis of a paraboloid hits its vertex once",
+			object: Paraboloid(),
+			ray:    Ray(Point(0, 5, 0), Vector(0, -1, 0)),
+			want:   []float64{5},
+		},
+		{
+			name:   "A ray below a paraboloid misses",
+			object: Paraboloid(),
+			ray:    Ray(Point(-5, -1, 0), Vector(1, 0, 0)),
+			want:   nil,
+		},
+		{
+			name:   "A ray through the waist of a hyperboloid of one sheet",
+			object: HyperboloidOneSheet(),
+			ray:    Ray(Point(-5, 0, 0), Vector(1, 0, 0)),
+			want:   []float64{4, 6},
+		},
+		{
+			name:   "A ray above the waist of a hyperboloid of one sheet",
+			object: HyperboloidOneSheet(),
+			ray:    Ray(Point(-5, 1, 0), Vector(1, 0, 0)),
+			want:   []float64{5 - sq2, 5 + sq2},
+		},
+		{
+			name:   "A ray down the axis of a hyperboloid of two sheets",
+			object: HyperboloidTwoSheets(),
+			ray:    Ray(Point(0, 5, 0), Vector(0, -1, 0)),
+			want:   []float64{4, 6},
+		},
+		{
+			name:   "A ray between the sheets of a hyperboloid of two sheets misses",
+			object: HyperboloidTwoSheets(),
+			ray:    Ray(Point(-5, 0, 0), Vector(1, 0, 0)),
+			want:   nil,
+		},
+		{
+			name:   "A ray through an ellipsoid",
+			object: Ellipsoid(2, 1, 0.5),
+			ray:    Ray(Point(-5, 0, 0), Vector(1, 0, 0)),
+			want:   []float64{3, 7},
+		},
+		{
+			name:   "A ray through an ellipsoid along Z",
+			object: Ellipsoid(2, 1, 0.5),
+			ray:    Ray(Point(0, 0, -5), Vector(0, 0, 1)),
+			want:   []float64{4.5, 5.5},
+		},
+		{
+			name:   "A ray through a large ellipsoid",
+			object: Ellipsoid(200, 200, 200),
+			ray:    Ray(Point(0, 0, -500), Vector(0, 0, 1)),
+			want:   []float64{300, 700},
+		},
+		{
+			name:   "A ray through a large, flat ellipsoid",
+			object: Ellipsoid(1000, 1, 1000),
+			ray:    Ray(Point(-2000, 0, 0), Vector(1, 0, 0)),
+			want:   []float64{1000, 3000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xs := tt.object.LocalIntersect(tt.ray)
+			if len(xs) != len(tt.want) {
+				t.Fatalf("LocalIntersect = %v, want %v", xs, tt.want)
+			}
+			for i, w := range tt.want {
+				if math.Abs(xs[i].T-w) > epsilon {
+					t.Errorf("LocalIntersect[%v] = %v, want %v", i, xs[i].T, w)
+				}
+			}
+		})
+	}
+}
+
+func TestQuadric_IntersectConstrainedQuadric(t *testing.T) {
+	q := HyperboloidOneSheet()
+	q.Minimum = 1
+	q.Maximum = 2
+
+	tests := []struct {
+		ray   RayT
+		count int
+	}{
+		{ray: Ray(Point(0, 1.5, 0), Vector(0.1, 1, 0)), count: 0},
+		{ray: Ray(Point(0, 3, -5), Vector(0, 0, 1)), count: 0},
+		{ray: Ray(Point(0, 0, -5), Vector(0, 0, 1)), count: 0},
+		{ray: Ray(Point(0, 2, -5), Vector(0, 0, 1)), count: 0},
+		{ray: Ray(Point(0, 1, -5), Vector(0, 0, 1)), count: 0},
+		{ray: Ray(Point(0, 1.5, -5), Vector(0, 0, 1)), count: 2},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test #%v", i+1), func(t *testing.T) {
+			if got := q.LocalIntersect(tt.ray); len(got) != tt.count {
+				t.Errorf("LocalIntersect = %v, want %v intersections", got, tt.count)
+			}
+		})
+	}
+}
+
+func TestQuadric_IntersectCapsOfClosedQuadric(t *testing.T) {
+	q := Paraboloid()
+	q.Minimum = 0
+	q.Maximum = 1
+	q.Closed = true
+
+	tests := []struct {
+		ray  RayT
+		want []float64
+	}{
+		{ray: Ray(Point(0, 3, 0), Vector(0, -1, 0)), want: []float64{3, 2}},
+		{ray: Ray(Point(0.5, 3, 0), Vector(0, -1, 0)), want: []float64{2.75, 2}},
+		{ray: Ray(Point(1.5, 3, 0), Vector(0, -1, 0)), want: nil},
+		{ray: Ray(Point(0, 0.5, -5), Vector(0, 0, 1)), want: []float64{4.29289, 5.70711}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test #%v", i+1), func(t *testing.T) {
+			xs := q.LocalIntersect(tt.ray)
+			if len(xs) != len(tt.want) {
+				t.Fatalf("LocalIntersect = %v, want %v", xs, tt.want)
+			}
+			for i, w := range tt.want {
+				if math.Abs(xs[i].T-w) > epsilon {
+					t.Errorf("LocalIntersect[%v] = %v, want %v", i, xs[i].T, w)
+				}
+			}
+		})
+	}
+}
+
+func TestQuadricT_LocalNormalAt(t *testing.T) {
+	sq2 := math.Sqrt2 / 2
+	capped := Paraboloid()
+	capped.Minimum = 0
+	capped.Maximum = 1
+	capped.Closed = true
+
+	tests := []struct {
+		object *QuadricT
+		point  Tuple
+		want   Tuple
+	}{
+		{object: Paraboloid(), point: Point(0, 0, 0), want: Vector(0, -1, 0)},
+		{object: Paraboloid(), point: Point(0.5, 0.25, 0), want: Vector(sq2, -sq2, 0)},
+		{object: HyperboloidOneSheet(), point: Point(1, 0, 0), want: Vector(1, 0, 0)},
+		{object: HyperboloidTwoSheets(), point: Point(0, 1, 0), want: Vector(0, -1, 0)},
+		{object: Ellipsoid(2, 1, 0.5), point: Point(2, 0, 0), want: Vector(1, 0, 0)},
+		{object: capped, point: Point(0.5, 1, 0), want: Vector(0, 1, 0)},
+		{object: capped, point: Point(0.5, 0.25, 0), want: Vector(sq2, -sq2, 0)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test #%v", i+1), func(t *testing.T) {
+			if got := tt.object.LocalNormalAt(tt.point, nil).Normalize(); !got.Equal(tt.want) {
+				t.Errorf("LocalNormalAt(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuadricT_Bounds(t *testing.T) {
+	sq3 := math.Sqrt(3)
+	inf := math.Inf(1)
+
+	clip := func(q *QuadricT, min, max float64) *QuadricT {
+		q.Minimum = min
+		q.Maximum = max
+		return q
+	}
+
+	tests := []struct {
+		name    string
+		object  *QuadricT
+		wantMin Tuple
+		wantMax Tuple
+	}{
+		{
+			name:    "An unclipped paraboloid is unbounded",
+			object:  Paraboloid(),
+			wantMin: Point(-inf, -inf, -inf),
+			wantMax: Point(inf, inf, inf),
+		},
+		{
+			name:    "A clipped paraboloid",
+			object:  clip(Paraboloid(), -1, 4),
+			wantMin: Point(-2, 0, -2),
+			wantMax: Point(2, 4, 2),
+		},
+		{
+			name:    "A clipped hyperboloid of one sheet",
+			object:  clip(HyperboloidOneSheet(), -1, 2),
+			wantMin: Point(-math.Sqrt(5), -1, -math.Sqrt(5)),
+			wantMax: Point(math.Sqrt(5), 2, math.Sqrt(5)),
+		},
+		{
+			name:    "A clipped hyperboloid of two sheets",
+			object:  clip(HyperboloidTwoSheets(), -2, 0.5),
+			wantMin: Point(-sq3, -2, -sq3),
+			wantMax: Point(sq3, -1, sq3),
+		},
+		{
+			name:    "An ellipsoid is bounded without clipping",
+			object:  Ellipsoid(2, 1, 0.5),
+			wantMin: Point(-2, -1, -0.5),
+			wantMax: Point(2, 1, 0.5),
+		},
+		{
+			name:    "A large, flat ellipsoid",
+			object:  Ellipsoid(1000, 1, 1000),
+			wantMin: Point(-1000, -1, -1000),
+			wantMax: Point(1000, 1, 1000),
+		},
+		{
+			name:    "A clipped ellipsoid",
+			object:  clip(Ellipsoid(1, 1, 1), 0, inf),
+			wantMin: Point(-1, 0, -1),
+			wantMax: Point(1, 1, 1),
+		},
+		{
+			name:    "A translated ellipsoid",
+			object:  Quadric(1, 1, 1, 0, 0, 0, -2, 0, 0, 0),
+			wantMin: Point(0, -1, -1),
+			wantMax: Point(2, 1, 1),
+		},
+	}
+
+	equal := func(a, b Tuple) bool {
+		for i := 0; i < 3; i++ {
+			if math.IsInf(a[i], 0) || math.IsInf(b[i], 0) {
+				if a[i] != b[i] {
+					return false
+				}
+				continue
+			}
+			if math.Abs(a[i]-b[i]) > epsilon {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.object.Bounds()
+			if !equal(b.Min, tt.wantMin) || !equal(b.Max, tt.wantMax) {
+				t.Errorf("Bounds = %v, want (%v)-(%v)", b, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestQuadric_InGroupAndCSG(t *testing.T) {
+	q := Ellipsoid(2, 1, 1)
+	g := Group(q)
+	if got, want := g.Bounds().Max, Point(2, 1, 1); !got.Equal(want) {
+		t.Errorf("Group.Bounds.Max = %v, want %v", got, want)
+	}
+
+	c := CSG(CSGDifference, Ellipsoid(2, 1, 1), Sphere().SetTransform(Translation(2, 0, 0)))
+	xs := Intersect(c, Ray(Point(-5, 0, 0), Vector(1, 0, 0)))
+	if len(xs) != 2 || math.Abs(xs[0].T-3) > epsilon || math.Abs(xs[1].T-6) > epsilon {
+		t.Errorf("CSG intersections = %v, want t=3 and t=6", xs)
+	}
+}
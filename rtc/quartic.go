@@ -0,0 +1,193 @@
+package rtc
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// eqnEpsilon is the tolerance used by the polynomial solvers to decide
+	// whether a value is zero.
+	eqnEpsilon = 1e-9
+
+	// polishIterations is the number of Newton-Raphson steps used to refine
+	// each root found by solveQuartic.
+	polishIterations = 4
+)
+
+func isZero(v float64) bool {
+	return math.Abs(v) < eqnEpsilon
+}
+
+// solveQuadratic returns the real roots of c2*x^2 + c1*x + c0 = 0.
+func solveQuadratic(c2, c1, c0 float64) []float64 {
+	if isZero(c2) {
+		if isZero(c1) {
+			return nil
+		}
+		return []float64{-c0 / c1}
+	}
+
+	p := c1 / (2 * c2)
+	q := c0 / c2
+	d := p*p - q
+
+	if isZero(d) {
+		return []float64{-p}
+	}
+	if d < 0 {
+		return nil
+	}
+
+	sd := math.Sqrt(d)
+	return []float64{sd - p, -sd - p}
+}
+
+// solveCubic returns the real roots of c3*x^3 + c2*x^2 + c1*x + c0 = 0
+// using Cardano's method.
+func solveCubic(c3, c2, c1, c0 float64) []float64 {
+	if isZero(c3) {
+		return solveQuadratic(c2, c1, c0)
+	}
+
+	// Normal form: x^3 + Ax^2 + Bx + C = 0
+	a := c2 / c3
+	b := c1 / c3
+	c := c0 / c3
+
+	// Substitute x = y - A/3 to eliminate the quadratic term:
+	// y^3 + 3py + 2q = 0
+	sqA := a * a
+	p := (-sqA/3 + b) / 3
+	q := (2*a*sqA/27 - a*b/3 + c) / 2
+
+	// Use Cardano's formula.
+	cbP := p * p * p
+	d := q*q + cbP
+
+	var roots []float64
+	switch {
+	case isZero(d):
+		if isZero(q) { // one triple solution
+			roots = []float64{0}
+		} else { // one single and one double solution
+			u := math.Cbrt(-q)
+			roots = []float64{2 * u, -u}
+		}
+	case d < 0: // Casus irreducibilis: three real solutions
+		phi := math.Acos(-q/math.Sqrt(-cbP)) / 3
+		t := 2 * math.Sqrt(-p)
+		roots = []float64{
+			t * math.Cos(phi),
+			-t * math.Cos(phi+math.Pi/3),
+			-t * math.Cos(phi-math.Pi/3),
+		}
+	default: // one real solution
+		sd := math.Sqrt(d)
+		u := math.Cbrt(sd - q)
+		v := -math.Cbrt(sd + q)
+		roots = []float64{u + v}
+	}
+
+	sub := a / 3
+	for i := range roots {
+		roots[i] -= sub
+	}
+	return roots
+}
+
+// solveQuartic returns the real roots of
+// c4*x^4 + c3*x^3 + c2*x^2 + c1*x + c0 = 0 in ascending order using
+// Ferrari's method. Each root is then polished with a few Newton-Raphson
+// iterations on the original polynomial to reduce the error introduced by
+// the closed-form solution.
+func solveQuartic(c4, c3, c2, c1, c0 float64) []float64 {
+	if isZero(c4) {
+		return solveCubic(c3, c2, c1, c0)
+	}
+
+	// Normal form: x^4 + Ax^3 + Bx^2 + Cx + D = 0
+	a := c3 / c4
+	b := c2 / c4
+	c := c1 / c4
+	d := c0 / c4
+
+	// Substitute x = y - A/4 to eliminate the cubic term:
+	// y^4 + py^2 + qy + r = 0
+	sqA := a * a
+	p := -3*sqA/8 + b
+	q := sqA*a/8 - a*b/2 + c
+	r := -3*sqA*sqA/256 + sqA*b/16 - a*c/4 + d
+
+	var roots []float64
+	if isZero(r) {
+		// No absolute term: y(y^3 + py + q) = 0
+		roots = append(solveCubic(1, 0, p, q), 0)
+	} else {
+		// Solve the resolvent cubic and take its first real root.
+		zs := solveCubic(1, -p/2, -r, r*p/2-q*q/8)
+		if len(zs) == 0 {
+			return nil
+		}
+		z := zs[0]
+
+		// Build the two quadratic equations.
+		u := z*z - r
+		v := 2*z - p
+
+		switch {
+		case isZero(u):
+			u = 0
+		case u > 0:
+			u = math.Sqrt(u)
+		default:
+			return nil
+		}
+
+		switch {
+		case isZero(v):
+			v = 0
+		case v > 0:
+			v = math.Sqrt(v)
+		default:
+			return nil
+		}
+
+		if q < 0 {
+			roots = append(solveQuadratic(1, -v, z-u), solveQuadratic(1, v, z+u)...)
+		} else {
+			roots = append(solveQuadratic(1, v, z-u), solveQuadratic(1, -v, z+u)...)
+		}
+	}
+
+	sub := a / 4
+	for i := range roots {
+		roots[i] = polishQuarticRoot(c4, c3, c2, c1, c0, roots[i]-sub)
+	}
+	sort.Float64s(roots)
+	return roots
+}
+
+// polishQuarticRoot refines the provided root of the quartic with Newton's
+// method, keeping the original value if the iteration does not improve it.
+func polishQuarticRoot(c4, c3, c2, c1, c0, x float64) float64 {
+	eval := func(x float64) (float64, float64) {
+		f := (((c4*x+c3)*x+c2)*x+c1)*x + c0
+		df := ((4*c4*x+3*c3)*x+2*c2)*x + c1
+		return f, df
+	}
+
+	best := x
+	bestF, _ := eval(x)
+	for i := 0; i < polishIterations; i++ {
+		f, df := eval(x)
+		if df == 0 {
+			break
+		}
+		x -= f / df
+		if nf, _ := eval(x); math.Abs(nf) < math.Abs(bestF) {
+			best, bestF = x, nf
+		}
+	}
+	return best
+}
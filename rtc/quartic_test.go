@@ -0,0 +1,99 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolveQuadratic(t *testing.T) {
+	tests := []struct {
+		name       string
+		c2, c1, c0 float64
+		want       []float64
+	}{
+		{name: "two roots", c2: 1, c1: -3, c0: 2, want: []float64{1, 2}},
+		{name: "double root", c2: 1, c1: -2, c0: 1, want: []float64{1}},
+		{name: "no real roots", c2: 1, c1: 0, c0: 1, want: nil},
+		{name: "linear", c2: 0, c1: 2, c0: -4, want: []float64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkRoots(t, solveQuadratic(tt.c2, tt.c1, tt.c0), tt.want)
+		})
+	}
+}
+
+func TestSolveCubic(t *testing.T) {
+	tests := []struct {
+		name           string
+		c3, c2, c1, c0 float64
+		want           []float64
+	}{
+		{name: "three roots", c3: 1, c2: -6, c1: 11, c0: -6, want: []float64{1, 2, 3}},
+		{name: "one real root", c3: 1, c2: 0, c1: 1, c0: -2, want: []float64{1}},
+		{name: "single and double root", c3: 1, c2: -4, c1: 5, c0: -2, want: []float64{1, 2}},
+		{name: "triple root", c3: 2, c2: -6, c1: 6, c0: -2, want: []float64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkRoots(t, solveCubic(tt.c3, tt.c2, tt.c1, tt.c0), tt.want)
+		})
+	}
+}
+
+func TestSolveQuartic(t *testing.T) {
+	tests := []struct {
+		name               string
+		c4, c3, c2, c1, c0 float64
+		want               []float64
+	}{
+		{name: "four roots", c4: 1, c3: -10, c2: 35, c1: -50, c0: 24, want: []float64{1, 2, 3, 4}},
+		{name: "four symmetric roots", c4: 1, c3: 0, c2: -5, c1: 0, c0: 4, want: []float64{-2, -1, 1, 2}},
+		{name: "two roots", c4: 1, c3: 0, c2: 0, c1: 0, c0: -16, want: []float64{-2, 2}},
+		{name: "no real roots", c4: 1, c3: 0, c2: 2, c1: 0, c0: 1, want: nil},
+		{name: "zero root", c4: 1, c3: -6, c2: 11, c1: -6, c0: 0, want: []float64{0, 1, 2, 3}},
+		{name: "widely spread roots", c4: 1, c3: -1001.001, c2: 1001.001, c1: -1, c0: 0, want: []float64{0, 0.001, 1, 1000}},
+		{name: "scaled coefficients", c4: 3, c3: -30, c2: 105, c1: -150, c0: 72, want: []float64{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkRoots(t, solveQuartic(tt.c4, tt.c3, tt.c2, tt.c1, tt.c0), tt.want)
+		})
+	}
+}
+
+// checkRoots compares the unique roots found against the wanted roots.
+func checkRoots(t *testing.T, got, want []float64) {
+	t.Helper()
+
+	var unique []float64
+outer:
+	for _, root := range got {
+		for _, u := range unique {
+			if math.Abs(u-root) < epsilon {
+				continue outer
+			}
+		}
+		unique = append(unique, root)
+	}
+
+	if len(unique) != len(want) {
+		t.Fatalf("roots = %v, want %v", got, want)
+	}
+
+	for _, w := range want {
+		var found bool
+		for _, u := range unique {
+			if math.Abs(u-w) < epsilon {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("roots = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package rtc
+
+import (
+	"log"
+	"math"
+)
+
+// DistanceField is implemented by signed distance functions which are
+// rendered by sphere tracing with the SDFT shape.
+type DistanceField interface {
+	// Distance returns the signed distance from the object space point to
+	// the nearest surface. It is negative inside the surface, positive
+	// outside, and must never overestimate the true distance.
+	Distance(p Tuple) float64
+}
+
+// DistanceFunc is an adapter that allows an ordinary function to be used
+// as a DistanceField.
+type DistanceFunc func(p Tuple) float64
+
+// Distance returns f(p).
+func (f DistanceFunc) Distance(p Tuple) float64 {
+	return f(p)
+}
+
+// SDFSphere returns the distance field of a sphere centered at the origin.
+func SDFSphere(radius float64) DistanceField {
+	return DistanceFunc(func(p Tuple) float64 {
+		return math.Sqrt(p.X()*p.X()+p.Y()*p.Y()+p.Z()*p.Z()) - radius
+	})
+}
+
+// SDFBox returns the distance field of an axis-aligned box centered at the
+// origin with the provided half-extents along each axis.
+func SDFBox(hx, hy, hz float64) DistanceField {
+	return SDFRoundedBox(hx, hy, hz, 0)
+}
+
+// SDFRoundedBox returns the distance field of an axis-aligned box centered
+// at the origin with the provided half-extents whose edges and corners are
+// rounded with the given radius. The rounding stays within the half-extents.
+func SDFRoundedBox(hx, hy, hz, radius float64) DistanceField {
+	return DistanceFunc(func(p Tuple) float64 {
+		qx := math.Abs(p.X()) - hx + radius
+		qy := math.Abs(p.Y()) - hy + radius
+		qz := math.Abs(p.Z()) - hz + radius
+		outside := math.Sqrt(square(math.Max(qx, 0)) + square(math.Max(qy, 0)) + square(math.Max(qz, 0)))
+		inside := math.Min(math.Max(qx, math.Max(qy, qz)), 0)
+		return outside + inside - radius
+	})
+}
+
+// SDFTorus returns the distance field of a torus centered at the origin
+// lying in the X-Z plane, like the Torus shape.
+func SDFTorus(majorRadius, minorRadius float64) DistanceField {
+	return DistanceFunc(func(p Tuple) float64 {
+		qx := math.Sqrt(p.X()*p.X()+p.Z()*p.Z()) - majorRadius
+		return math.Sqrt(qx*qx+p.Y()*p.Y()) - minorRadius
+	})
+}
+
+// SDFCapsule returns the distance field of a capsule (a cylinder with
+// hemispherical ends) around the line segment from a to b.
+func SDFCapsule(a, b Tuple, radius float64) DistanceField {
+	ab := b.Sub(a)
+	abLen2 := ab.Dot(ab)
+	return DistanceFunc(func(p Tuple) float64 {
+		ap := p.Sub(a)
+		h := 0.0
+		if abLen2 > 0 {
+			h = math.Max(0, math.Min(1, ap.Dot(ab)/abLen2))
+		}
+		return ap.Sub(ab.MultScalar(h)).Magnitude() - radius
+	})
+}
+
+// SDFSmoothUnion returns the union of the fields, blended together within
+// the distance k of each other. A k of zero produces a hard union.
+func SDFSmoothUnion(k float64, fields ...DistanceField) DistanceField {
+	if len(fields) == 0 {
+		log.Fatalf("programming error - SDFSmoothUnion needs at least one field")
+	}
+	return DistanceFunc(func(p Tuple) float64 {
+		d := fields[0].Distance(p)
+		for _, f := range fields[1:] {
+			d = smoothMin(d, f.Distance(p), k)
+		}
+		return d
+	})
+}
+
+// SDFSubtraction returns field a with field b carved out of it, blended
+// within the distance k. A k of zero produces a hard subtraction.
+func SDFSubtraction(k float64, a, b DistanceField) DistanceField {
+	return DistanceFunc(func(p Tuple) float64 {
+		return -smoothMin(-a.Distance(p), b.Distance(p), k)
+	})
+}
+
+// SDFIntersection returns the intersection of the fields, blended within
+// the distance k. A k of zero produces a hard intersection.
+func SDFIntersection(k float64, fields ...DistanceField) DistanceField {
+	if len(fields) == 0 {
+		log.Fatalf("programming error - SDFIntersection needs at least one field")
+	}
+	return DistanceFunc(func(p Tuple) float64 {
+		d := fields[0].Distance(p)
+		for _, f := range fields[1:] {
+			d = -smoothMin(-d, -f.Distance(p), k)
+		}
+		return d
+	})
+}
+
+// SDFRepetition returns the field repeated infinitely with the provided
+// period along each axis. A period of zero disables repetition along that
+// axis. The field should fit within a single period for correct results.
+func SDFRepetition(field DistanceField, px, py, pz float64) DistanceField {
+	repeat := func(v, period float64) float64 {
+		if period <= 0 {
+			return v
+		}
+		return v - period*math.Round(v/period)
+	}
+	return DistanceFunc(func(p Tuple) float64 {
+		return field.Distance(Point(repeat(p.X(), px), repeat(p.Y(), py), repeat(p.Z(), pz)))
+	})
+}
+
+// SDFTwist returns the field twisted around the Y axis by rate radians per
+// unit of height. Twisting distorts distances, so the SDFT StepScale should
+// usually be reduced for strongly twisted fields.
+func SDFTwist(field DistanceField, rate float64) DistanceField {
+	return DistanceFunc(func(p Tuple) float64 {
+		angle := rate * p.Y()
+		c, s := math.Cos(angle), math.Sin(angle)
+		return field.Distance(Point(c*p.X()-s*p.Z(), p.Y(), s*p.X()+c*p.Z()))
+	})
+}
+
+// smoothMin returns the polynomial smooth minimum of a and b with the
+// blending distance k.
+func smoothMin(a, b, k float64) float64 {
+	if k <= 0 {
+		return math.Min(a, b)
+	}
+	h := math.Max(k-math.Abs(a-b), 0) / k
+	return math.Min(a, b) - h*h*k/4
+}
+
+func square(v float64) float64 {
+	return v * v
+}
+
+const (
+	// sdfMaxSteps is the default maximum number of sphere tracing steps.
+	sdfMaxSteps = 512
+
+	// sdfMinStep is the smallest step taken while sphere tracing so that
+	// the ray always makes progress past a surface.
+	sdfMinStep = epsilon / 10
+
+	// sdfBisections is the number of bisection steps used to refine a
+	// surface crossing.
+	sdfBisections = 32
+)
+
+// SDF creates a shape from the signed distance field which is rendered
+// by sphere tracing. Distance fields have no inherent extent, so the
+// (finite) object space bounds of the surface must be provided.
+// It implements the Object interface.
+func SDF(field DistanceField, bounds *BoundsT) *SDFT {
+	for i := 0; i < 3; i++ {
+		if math.IsInf(bounds.Min[i], 0) || math.IsInf(bounds.Max[i], 0) {
+			log.Fatalf("programming error - SDF bounds must be finite, got %v", bounds)
+		}
+	}
+	return &SDFT{
+		Shape:     Shape{Transform: M4Identity(), Material: GetMaterial()},
+		Field:     field,
+		MaxSteps:  sdfMaxSteps,
+		StepScale: 1,
+		bounds:    bounds,
+	}
+}
+
+// SDFT represents a shape defined by a signed distance field.
+type SDFT struct {
+	Shape
+	Field DistanceField
+
+	// MaxSteps is the maximum number of steps taken along each ray.
+	MaxSteps int
+	// StepScale scales each step (in the range (0,1]) for fields that
+	// overestimate distances, such as twisted fields.
+	StepScale float64
+
+	bounds *BoundsT
+}
+
+var _ Object = &SDFT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (s *SDFT) SetTransform(m M4) Object {
+	s.Transform = m
+	return s
+}
+
+// SetMaterial sets the object's material.
+func (s *SDFT) SetMaterial(material MaterialT) Object {
+	s.setMaterial(material)
+	return s
+}
+
+// SetParent sets the object's parent object.
+func (s *SDFT) SetParent(parent Object) Object {
+	s.Parent = parent
+	return s
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (s *SDFT) Bounds() *BoundsT {
+	return s.bounds
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+// The ray is sphere traced through the bounding box and every crossing of
+// the surface is refined by bisection, so both entering and exiting
+// intersections are returned.
+func (s *SDFT) LocalIntersect(ray RayT) []IntersectionT {
+	bxs := s.bounds.LocalIntersect(ray, s)
+	if len(bxs) == 0 {
+		return nil
+	}
+
+	// March along a unit direction so that steps match field distances.
+	length := ray.Direction.Magnitude()
+	origin := ray.Position(bxs[0].T)
+	direction := ray.Direction.DivScalar(length)
+	end := (bxs[1].T - bxs[0].T) * length
+
+	at := func(d float64) float64 {
+		return s.Field.Distance(origin.Add(direction.MultScalar(d)))
+	}
+
+	var xs []IntersectionT
+	d0, f0 := 0.0, at(0)
+	for i := 0; i < s.MaxSteps && d0 < end; i++ {
+		d1 := math.Min(d0+math.Max(math.Abs(f0)*s.StepScale, sdfMinStep), end)
+		f1 := at(d1)
+
+		if (f0 < 0) != (f1 < 0) {
+			root := s.refine(at, d0, d1, f0)
+			xs = append(xs, Intersection(bxs[0].T+root/length, s))
+		}
+		d0, f0 = d1, f1
+	}
+
+	return xs
+}
+
+// refine bisects the interval [lo,hi] containing a sign change of the
+// field and returns the distance along the ray to the surface.
+func (s *SDFT) refine(at func(float64) float64, lo, hi, fLo float64) float64 {
+	for i := 0; i < sdfBisections; i++ {
+		mid := (lo + hi) / 2
+		fMid := at(mid)
+		if (fMid < 0) == (fLo < 0) {
+			lo, fLo = mid, fMid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+// It is estimated from the gradient of the distance field using central
+// differences.
+func (s *SDFT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	const h = epsilon / 10
+	dx := Vector(h, 0, 0)
+	dy := Vector(0, h, 0)
+	dz := Vector(0, 0, h)
+	return Vector(
+		s.Field.Distance(objectPoint.Add(dx))-s.Field.Distance(objectPoint.Sub(dx)),
+		s.Field.Distance(objectPoint.Add(dy))-s.Field.Distance(objectPoint.Sub(dy)),
+		s.Field.Distance(objectPoint.Add(dz))-s.Field.Distance(objectPoint.Sub(dz)),
+	).Normalize()
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (s *SDFT) Includes(other Object) bool {
+	return s == other
+}
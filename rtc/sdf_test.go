@@ -0,0 +1,157 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceField_Primitives(t *testing.T) {
+	tests := []struct {
+		name  string
+		field DistanceField
+		point Tuple
+		want  float64
+	}{
+		{name: "sphere outside", field: SDFSphere(1), point: Point(0, 3, 0), want: 2},
+		{name: "sphere inside", field: SDFSphere(2), point: Point(0, 0, 0), want: -2},
+		{name: "box face", field: SDFBox(1, 2, 3), point: Point(3, 0, 0), want: 2},
+		{name: "box corner", field: SDFBox(1, 1, 1), point: Point(2, 2, 1), want: math.Sqrt2},
+		{name: "box inside", field: SDFBox(1, 2, 3), point: Point(0, 1.5, 0), want: -0.5},
+		{name: "rounded box face", field: SDFRoundedBox(1, 1, 1, 0.25), point: Point(0, 0, 2), want: 1},
+		{name: "rounded box corner", field: SDFRoundedBox(1, 1, 1, 0.25), point: Point(1, 1, 1), want: 0.25*math.Sqrt(3) - 0.25},
+		{name: "torus tube center", field: SDFTorus(1, 0.25), point: Point(0, 0, 1), want: -0.25},
+		{name: "torus axis", field: SDFTorus(1, 0.25), point: Point(0, 0, 0), want: 0.75},
+		{name: "capsule side", field: SDFCapsule(Point(0, -1, 0), Point(0, 1, 0), 0.5), point: Point(2, 0.5, 0), want: 1.5},
+		{name: "capsule end", field: SDFCapsule(Point(0, -1, 0), Point(0, 1, 0), 0.5), point: Point(0, 3, 0), want: 1.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.field.Distance(tt.point); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("Distance(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistanceField_Operators(t *testing.T) {
+	left := DistanceFunc(func(p Tuple) float64 { return SDFSphere(1).Distance(p.Add(Vector(1, 0, 0))) })
+	right := DistanceFunc(func(p Tuple) float64 { return SDFSphere(1).Distance(p.Sub(Vector(1, 0, 0))) })
+
+	tests := []struct {
+		name  string
+		field DistanceField
+		point Tuple
+		want  float64
+	}{
+		{name: "hard union", field: SDFSmoothUnion(0, left, right), point: Point(0, 1, 0), want: math.Sqrt2 - 1},
+		{name: "smooth union", field: SDFSmoothUnion(0.5, left, right), point: Point(0, 1, 0), want: math.Sqrt2 - 1 - 0.125},
+		{name: "smooth union far away", field: SDFSmoothUnion(0.5, left, right), point: Point(5, 0, 0), want: 3},
+		{name: "subtraction", field: SDFSubtraction(0, SDFSphere(2), SDFSphere(1)), point: Point(0, 0, 0), want: 1},
+		{name: "subtraction outside", field: SDFSubtraction(0, SDFSphere(2), SDFSphere(1)), point: Point(3, 0, 0), want: 1},
+		{name: "intersection", field: SDFIntersection(0, left, right), point: Point(0, 0, 0), want: 0},
+		{name: "intersection inside", field: SDFIntersection(0, SDFSphere(2), SDFBox(1, 1, 1)), point: Point(0, 0.5, 0), want: -0.5},
+		{name: "repetition", field: SDFRepetition(SDFSphere(1), 4, 0, 0), point: Point(8, 2, 0), want: 1},
+		{name: "repetition disabled axis", field: SDFRepetition(SDFSphere(1), 4, 0, 0), point: Point(0, 8, 0), want: 7},
+		{name: "twist at origin", field: SDFTwist(SDFBox(2, 1, 0.5), math.Pi/2), point: Point(0, 0, 1), want: 0.5},
+		{name: "twist rotated", field: SDFTwist(SDFBox(2, 1, 0.5), math.Pi), point: Point(0, 0.5, 1.5), want: -0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.field.Distance(tt.point); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("Distance(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSDFT_LocalIntersect(t *testing.T) {
+	sphere := SDF(SDFSphere(1), &BoundsT{Min: Point(-1, -1, -1), Max: Point(1, 1, 1)})
+	hollow := SDF(SDFSubtraction(0, SDFSphere(2), SDFSphere(1)), &BoundsT{Min: Point(-2, -2, -2), Max: Point(2, 2, 2)})
+
+	tests := []struct {
+		name   string
+		s      *SDFT
+		ray    RayT
+		wantTs []float64
+	}{
+		{
+			name:   "A ray through the center of the sphere",
+			s:      sphere,
+			ray:    Ray(Point(0, 0, -5), Vector(0, 0, 1)),
+			wantTs: []float64{4, 6},
+		},
+		{
+			name:   "A ray with a scaled direction",
+			s:      sphere,
+			ray:    Ray(Point(0, 0, -5), Vector(0, 0, 2)),
+			wantTs: []float64{2, 3},
+		},
+		{
+			name:   "A ray originating inside the sphere",
+			s:      sphere,
+			ray:    Ray(Point(0, 0, 0), Vector(0, 0, 1)),
+			wantTs: []float64{-1, 1},
+		},
+		{
+			name: "A ray missing the sphere",
+			s:    sphere,
+			ray:  Ray(Point(0, 2, -5), Vector(0, 0, 1)),
+		},
+		{
+			name: "A ray inside the bounds that misses the sphere",
+			s:    sphere,
+			ray:  Ray(Point(0.9, 0.9, -5), Vector(0, 0, 1)),
+		},
+		{
+			name:   "A ray through a hollow sphere",
+			s:      hollow,
+			ray:    Ray(Point(0, 0, -5), Vector(0, 0, 1)),
+			wantTs: []float64{3, 4, 6, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.s.LocalIntersect(tt.ray)
+			if len(got) != len(tt.wantTs) {
+				t.Fatalf("LocalIntersect = %v, want %v intersections", got, len(tt.wantTs))
+			}
+			for i, want := range tt.wantTs {
+				if math.Abs(got[i].T-want) > epsilon {
+					t.Errorf("LocalIntersect[%v].T = %v, want %v", i, got[i].T, want)
+				}
+				if got[i].Object != tt.s {
+					t.Errorf("LocalIntersect[%v].Object = %v, want %v", i, got[i].Object, tt.s)
+				}
+			}
+		})
+	}
+}
+
+func TestSDFT_LocalNormalAt(t *testing.T) {
+	sphere := SDF(SDFSphere(1), &BoundsT{Min: Point(-1, -1, -1), Max: Point(1, 1, 1)})
+	box := SDF(SDFBox(1, 1, 1), &BoundsT{Min: Point(-1, -1, -1), Max: Point(1, 1, 1)})
+	s3 := math.Sqrt(3) / 3
+
+	tests := []struct {
+		name  string
+		s     *SDFT
+		point Tuple
+		want  Tuple
+	}{
+		{name: "sphere x", s: sphere, point: Point(1, 0, 0), want: Vector(1, 0, 0)},
+		{name: "sphere diagonal", s: sphere, point: Point(s3, s3, s3), want: Vector(s3, s3, s3)},
+		{name: "box top", s: box, point: Point(0.5, 1, -0.2), want: Vector(0, 1, 0)},
+		{name: "box side", s: box, point: Point(-1, 0.3, 0.2), want: Vector(-1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.LocalNormalAt(tt.point, nil); !got.Equal(tt.want) {
+				t.Errorf("LocalNormalAt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package rtc
+
+import "math"
+
+const (
+	// minRoughness prevents the GGX distribution from collapsing to a
+	// delta function for perfectly smooth surfaces.
+	minRoughness = 0.01
+)
+
+// ShadingModel represents a BRDF that computes the direct (diffuse and
+// specular) contribution of a single light at a point on a surface.
+// The ambient term is handled by Lighting and is not part of the model.
+type ShadingModel interface {
+	// Shade returns the diffuse and specular contribution of a light with
+	// the given intensity. color is the surface color at the point, and
+	// lightV, eyeV, and normalV are unit vectors. Shade is only called when
+	// the light is on the same side of the surface as the normal.
+	Shade(material *MaterialT, color, intensity, lightV, eyeV, normalV Tuple) Tuple
+}
+
+// PhongT represents the Phong reflection model as described in the book.
+// It implements the ShadingModel interface.
+type PhongT struct{}
+
+var _ ShadingModel = &PhongT{}
+
+// Phong returns the Phong shading model.
+func Phong() *PhongT {
+	return &PhongT{}
+}
+
+// Shade returns the diffuse and specular contribution of a light.
+func (p *PhongT) Shade(material *MaterialT, color, intensity, lightV, eyeV, normalV Tuple) Tuple {
+	lightDotNormal := lightV.Dot(normalV)
+	diffuse := color.HadamardProduct(intensity).MultScalar(material.Diffuse * lightDotNormal)
+
+	reflectV := lightV.Negate().Reflect(normalV)
+	reflectDotEye := reflectV.Dot(eyeV)
+
+	specular := Color(0, 0, 0)
+	if reflectDotEye > 0 {
+		factor := math.Pow(reflectDotEye, material.Shininess)
+		specular = intensity.MultScalar(material.Specular * factor)
+	}
+
+	return diffuse.Add(specular)
+}
+
+// BlinnPhongT represents the Blinn-Phong reflection model which uses the
+// half-vector between the light and the eye instead of the reflection vector.
+// It implements the ShadingModel interface.
+type BlinnPhongT struct{}
+
+var _ ShadingModel = &BlinnPhongT{}
+
+// BlinnPhong returns the Blinn-Phong shading model.
+func BlinnPhong() *BlinnPhongT {
+	return &BlinnPhongT{}
+}
+
+// Shade returns the diffuse and specular contribution of a light.
+func (b *BlinnPhongT) Shade(material *MaterialT, color, intensity, lightV, eyeV, normalV Tuple) Tuple {
+	lightDotNormal := lightV.Dot(normalV)
+	diffuse := color.HadamardProduct(intensity).MultScalar(material.Diffuse * lightDotNormal)
+
+	halfV := lightV.Add(eyeV).Normalize()
+	halfDotNormal := halfV.Dot(normalV)
+
+	specular := Color(0, 0, 0)
+	if halfDotNormal > 0 {
+		factor := math.Pow(halfDotNormal, material.Shininess)
+		specular = intensity.MultScalar(material.Specular * factor)
+	}
+
+	return diffuse.Add(specular)
+}
+
+// CookTorranceT represents a physically based microfacet BRDF using the
+// GGX (Trowbridge-Reitz) normal distribution, the Smith-Schlick geometry
+// term, and Schlick's Fresnel approximation. It is parameterized by the
+// material's Metallic and Roughness values.
+// It implements the ShadingModel interface.
+type CookTorranceT struct{}
+
+var _ ShadingModel = &CookTorranceT{}
+
+// CookTorrance returns the Cook-Torrance GGX shading model.
+func CookTorrance() *CookTorranceT {
+	return &CookTorranceT{}
+}
+
+// Shade returns the diffuse and specular contribution of a light.
+func (c *CookTorranceT) Shade(material *MaterialT, color, intensity, lightV, eyeV, normalV Tuple) Tuple {
+	nDotL := lightV.Dot(normalV)
+	nDotV := math.Max(eyeV.Dot(normalV), 0)
+	halfV := lightV.Add(eyeV).Normalize()
+	nDotH := math.Max(halfV.Dot(normalV), 0)
+	vDotH := math.Max(halfV.Dot(eyeV), 0)
+
+	roughness := math.Max(material.Roughness, minRoughness)
+	metallic := math.Max(math.Min(material.Metallic, 1), 0)
+
+	// GGX normal distribution.
+	alpha2 := roughness * roughness * roughness * roughness
+	denom := nDotH*nDotH*(alpha2-1) + 1
+	d := alpha2 / (math.Pi * denom * denom)
+
+	// Smith-Schlick geometry term for direct lighting.
+	k := (roughness + 1) * (roughness + 1) / 8
+	g := (nDotV / (nDotV*(1-k) + k)) * (nDotL / (nDotL*(1-k) + k))
+
+	// Schlick's Fresnel approximation. Dielectrics reflect about 4% at
+	// normal incidence while metals tint the reflection with their color.
+	f0 := Color(0.04, 0.04, 0.04).MultScalar(1 - metallic).Add(color.MultScalar(metallic))
+	fw := math.Pow(1-vDotH, 5)
+	f := f0.MultScalar(1 - fw).Add(Color(fw, fw, fw))
+
+	specFactor := 0.0
+	if nDotV > 0 && nDotL > 0 {
+		specFactor = d * g / (4 * nDotL * nDotV)
+	}
+
+	specular := f.HadamardProduct(intensity).MultScalar(specFactor * material.Specular * nDotL)
+
+	kd := Color(1, 1, 1).Sub(f).MultScalar(1 - metallic)
+	diffuse := kd.HadamardProduct(color).HadamardProduct(intensity).MultScalar(material.Diffuse * nDotL)
+
+	return diffuse.Add(specular)
+}
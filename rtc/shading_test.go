@@ -0,0 +1,165 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestShadingModel_Shade(t *testing.T) {
+	sq2 := math.Sqrt2 / 2
+
+	tests := []struct {
+		name     string
+		model    ShadingModel
+		metallic float64
+		color    Tuple
+		lightV   Tuple
+		want     Tuple
+	}{
+		{
+			name:   "Phong with the light behind the eye",
+			model:  Phong(),
+			color:  Color(1, 1, 1),
+			lightV: Vector(0, 0, -1),
+			want:   Color(1.8, 1.8, 1.8),
+		},
+		{
+			name:   "Phong with the light offset 45°",
+			model:  Phong(),
+			color:  Color(1, 1, 1),
+			lightV: Vector(0, sq2, -sq2),
+			want:   Color(0.6364, 0.6364, 0.6364),
+		},
+		{
+			name:   "Blinn-Phong with the light behind the eye",
+			model:  BlinnPhong(),
+			color:  Color(1, 1, 1),
+			lightV: Vector(0, 0, -1),
+			want:   Color(1.8, 1.8, 1.8),
+		},
+		{
+			name:   "Blinn-Phong with the light offset 45°",
+			model:  BlinnPhong(),
+			color:  Color(1, 1, 1),
+			lightV: Vector(0, sq2, -sq2),
+			want:   Color(0.6364, 0.6364, 0.6364),
+		},
+		{
+			name:   "Cook-Torrance dielectric with the light behind the eye",
+			model:  CookTorrance(),
+			color:  Color(1, 0.5, 0.25),
+			lightV: Vector(0, 0, -1),
+			want:   Color(0.90984, 0.47784, 0.26184),
+		},
+		{
+			name:   "Cook-Torrance dielectric with the light offset 45°",
+			model:  CookTorrance(),
+			color:  Color(1, 0.5, 0.25),
+			lightV: Vector(0, sq2, -sq2),
+			want:   Color(0.61496, 0.30949, 0.15675),
+		},
+		{
+			name:     "Cook-Torrance metal tints its reflection",
+			model:    CookTorrance(),
+			metallic: 1,
+			color:    Color(1, 0.5, 0.25),
+			lightV:   Vector(0, 0, -1),
+			want:     Color(1.14592, 0.57296, 0.28648),
+		},
+		{
+			name:     "Cook-Torrance metal with the light offset 45°",
+			model:    CookTorrance(),
+			metallic: 1,
+			color:    Color(1, 0.5, 0.25),
+			lightV:   Vector(0, sq2, -sq2),
+			want:     Color(0.10044, 0.05022, 0.02511),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := GetMaterial()
+			m.Metallic = tt.metallic
+			eyeV := Vector(0, 0, -1)
+			normalV := Vector(0, 0, -1)
+
+			if got := tt.model.Shade(&m, tt.color, Color(1, 1, 1), tt.lightV, eyeV, normalV); !got.Equal(tt.want) {
+				t.Errorf("Shade = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCookTorranceT_Shade_ConservesEnergy(t *testing.T) {
+	// The directional albedo is the fraction of the light arriving from
+	// the whole hemisphere that is reflected toward the eye. Since the
+	// book's diffuse term omits the 1/π of the Lambertian BRDF, it is the
+	// integral of Shade over the hemisphere divided by π.
+	const steps = 256
+	normalV := Vector(0, 0, -1)
+
+	tests := []struct {
+		name      string
+		metallic  float64
+		roughness float64
+		eyeV      Tuple
+	}{
+		{name: "smooth dielectric", roughness: 0.2, eyeV: Vector(0, 0, -1)},
+		{name: "rough dielectric", roughness: 1, eyeV: Vector(0, 0, -1)},
+		{name: "grazing dielectric", roughness: 0.5, eyeV: Vector(0, 0.95, -math.Sqrt(1-0.95*0.95))},
+		{name: "smooth metal", metallic: 1, roughness: 0.2, eyeV: Vector(0, 0, -1)},
+		{name: "default metal", metallic: 1, roughness: 0.5, eyeV: Vector(0, 0, -1)},
+		{name: "rough metal", metallic: 1, roughness: 1, eyeV: Vector(0, 0, -1)},
+		{name: "grazing metal", metallic: 1, roughness: 0.5, eyeV: Vector(0, 0.95, -math.Sqrt(1-0.95*0.95))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := GetMaterial()
+			m.Diffuse = 1
+			m.Specular = 1
+			m.Metallic = tt.metallic
+			m.Roughness = tt.roughness
+			model := CookTorrance()
+
+			// Integrate over uniform steps of cos θ and φ, in which the
+			// solid angle is uniform.
+			var sum float64
+			for i := 0; i < steps; i++ {
+				cosTheta := (float64(i) + 0.5) / steps
+				sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+				for j := 0; j < steps; j++ {
+					sinPhi, cosPhi := math.Sincos(2 * math.Pi * (float64(j) + 0.5) / steps)
+					lightV := Vector(sinTheta*cosPhi, sinTheta*sinPhi, -cosTheta)
+					sum += model.Shade(&m, Color(1, 1, 1), Color(1, 1, 1), lightV, tt.eyeV, normalV).Red()
+				}
+			}
+			albedo := sum * (2 * math.Pi / (steps * steps)) / math.Pi
+
+			if albedo > 1+epsilon {
+				t.Errorf("directional albedo = %v, want at most 1", albedo)
+			}
+		})
+	}
+}
+
+func TestLighting_WithShadingModel(t *testing.T) {
+	s := Sphere()
+	m := GetMaterial()
+	m.Model = CookTorrance()
+	m.Metallic = 1
+	m.Color = Color(1, 0.5, 0.25)
+	eyeVector := Vector(0, 0, -1)
+	normalVector := Vector(0, 0, -1)
+	light := PointLight(Point(0, 0, -10), Color(1, 1, 1))
+
+	got := Lighting(&m, s, light, Point(0, 0, 0), eyeVector, normalVector, false)
+	if want := Color(1.24592, 0.62296, 0.31148); !got.Equal(want) {
+		t.Errorf("Lighting = %v, want %v", got, want)
+	}
+
+	got = Lighting(&m, s, light, Point(0, 0, 0), eyeVector, normalVector, true)
+	if want := Color(0.1, 0.05, 0.025); !got.Equal(want) {
+		t.Errorf("Lighting in shadow = %v, want %v", got, want)
+	}
+}
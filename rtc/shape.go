@@ -5,6 +5,10 @@ type Shape struct {
 	Transform M4
 	Material  MaterialT
 	Parent    Object
+
+	// InheritMaterial reports whether the shape uses the material of its
+	// nearest ancestor (such as a group or CSG) in place of its own.
+	InheritMaterial bool
 }
 
 // Transform returns the object's transform 4x4 matrix.
@@ -12,12 +16,53 @@ func (s *Shape) GetTransform() M4 {
 	return s.Transform
 }
 
-// Material returns the object's material.
+// Material returns the object's material, or the material of its parent
+// if the shape inherits its material. An inherited material is shared:
+// changes made through the returned pointer apply to the ancestor and to
+// every shape that inherits from it.
 func (s *Shape) GetMaterial() *MaterialT {
+	if s.InheritMaterial && s.Parent != nil {
+		return s.Parent.GetMaterial()
+	}
 	return &s.Material
 }
 
+// setMaterial sets the shape's own material, which it uses from then on
+// instead of inheriting the material of its ancestors.
+func (s *Shape) setMaterial(material MaterialT) {
+	s.Material = material
+	s.InheritMaterial = false
+}
+
 // Parent returns the object's parent object.
 func (s *Shape) GetParent() Object {
 	return s.Parent
 }
+
+// shape returns the common shape of the object.
+func (s *Shape) shape() *Shape {
+	return s
+}
+
+// shaper is implemented by all objects that embed a Shape.
+type shaper interface {
+	shape() *Shape
+}
+
+// InheritMaterial marks the objects so that they use the material of their
+// nearest ancestor at shading time instead of their own. Objects without
+// a parent keep using their own material, and SetMaterial gives an object
+// its own material again.
+func InheritMaterial(objects ...Object) {
+	for _, object := range objects {
+		if s, ok := object.(shaper); ok {
+			s.shape().InheritMaterial = true
+		}
+	}
+}
+
+// inheritsMaterial reports whether the object inherits its material.
+func inheritsMaterial(object Object) bool {
+	s, ok := object.(shaper)
+	return ok && s.shape().InheritMaterial
+}
@@ -30,7 +30,7 @@ func (s *Shape) SetTransform(m M4) Object {
 // SetMaterial sets the object's material.
 // Only for testing!
 func (s *Shape) SetMaterial(material MaterialT) Object {
-	s.Material = material
+	s.setMaterial(material)
 	return s
 }
 
@@ -189,3 +189,151 @@ func TestShape_NormalAt_WithTransform(t *testing.T) {
 		})
 	}
 }
+
+func TestShape_InheritMaterial(t *testing.T) {
+	red := GetMaterial()
+	red.Color = Color(1, 0, 0)
+	blue := GetMaterial()
+	blue.Color = Color(0, 0, 1)
+
+	tests := []struct {
+		name  string
+		setup func() Object
+		want  Tuple
+	}{
+		{
+			name: "no parent keeps own material",
+			setup: func() Object {
+				s := Sphere()
+				InheritMaterial(s)
+				return s
+			},
+			want: Color(1, 1, 1),
+		},
+		{
+			name: "group",
+			setup: func() Object {
+				s := Sphere()
+				InheritMaterial(s)
+				Group(s).SetMaterial(red)
+				return s
+			},
+			want: Color(1, 0, 0),
+		},
+		{
+			name: "own material without inheritance",
+			setup: func() Object {
+				s := Sphere()
+				s.SetMaterial(blue)
+				Group(s).SetMaterial(red)
+				return s
+			},
+			want: Color(0, 0, 1),
+		},
+		{
+			name: "nearest ancestor",
+			setup: func() Object {
+				s := Sphere()
+				inner := Group(s)
+				InheritMaterial(s, inner)
+				outer := Group(inner)
+				outer.SetMaterial(red)
+				Group(outer).SetMaterial(blue)
+				return s
+			},
+			want: Color(1, 0, 0),
+		},
+		{
+			name: "csg",
+			setup: func() Object {
+				s := Sphere()
+				InheritMaterial(s)
+				CSG(CSGDifference, s, Cube()).SetMaterial(blue)
+				return s
+			},
+			want: Color(0, 0, 1),
+		},
+		{
+			name: "material changed after inheriting",
+			setup: func() Object {
+				s := Sphere()
+				InheritMaterial(s)
+				g := Group(s)
+				g.SetMaterial(red)
+				g.Material.Color = Color(0, 1, 0)
+				return s
+			},
+			want: Color(0, 1, 0),
+		},
+		{
+			name: "SetMaterial stops inheriting",
+			setup: func() Object {
+				s := Sphere()
+				InheritMaterial(s)
+				Group(s).SetMaterial(red)
+				s.SetMaterial(blue)
+				return s
+			},
+			want: Color(0, 0, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.setup()
+			if got := s.GetMaterial().Color; !got.Equal(tt.want) {
+				t.Errorf("GetMaterial().Color = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShape_InheritMaterial_Shared(t *testing.T) {
+	s1 := Sphere()
+	s2 := Cube()
+	InheritMaterial(s1, s2)
+	g := Group(s1, s2)
+
+	// Modifying an inherited material modifies the material of the group,
+	// which all of its inheriting children share.
+	s1.GetMaterial().Color = Color(1, 0, 0)
+	if got, want := g.GetMaterial().Color, Color(1, 0, 0); !got.Equal(want) {
+		t.Errorf("group GetMaterial().Color = %v, want %v", got, want)
+	}
+	if got, want := s2.GetMaterial().Color, Color(1, 0, 0); !got.Equal(want) {
+		t.Errorf("sibling GetMaterial().Color = %v, want %v", got, want)
+	}
+	if !s1.InheritMaterial {
+		t.Error("InheritMaterial = false after modifying the inherited material, want true")
+	}
+
+	s1.SetMaterial(GetMaterial())
+	if s1.InheritMaterial {
+		t.Error("InheritMaterial = true after SetMaterial, want false")
+	}
+	s1.GetMaterial().Color = Color(0, 0, 1)
+	if got, want := g.GetMaterial().Color, Color(1, 0, 0); !got.Equal(want) {
+		t.Errorf("group GetMaterial().Color = %v after SetMaterial on child, want %v", got, want)
+	}
+}
+
+func TestShape_InheritMaterial_ShadeHit(t *testing.T) {
+	w := World()
+	w.Lights = []*PointLightT{PointLight(Point(-10, 10, -10), Color(1, 1, 1))}
+
+	s := Sphere()
+	InheritMaterial(s)
+	g := Group(s)
+	m := GetMaterial()
+	m.Color = Color(1, 0, 0)
+	m.Ambient = 1
+	m.Diffuse = 0
+	m.Specular = 0
+	g.SetMaterial(m)
+	w.Objects = []Object{g}
+
+	r := Ray(Point(0, 0, -5), Vector(0, 0, 1))
+	if got, want := w.ColorAt(r, 1), Color(1, 0, 0); !got.Equal(want) {
+		t.Errorf("ColorAt = %v, want %v", got, want)
+	}
+}
@@ -32,7 +32,7 @@ func (s *SmoothTriangleT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (s *SmoothTriangleT) SetMaterial(material MaterialT) Object {
-	s.Material = material
+	s.setMaterial(material)
 	return s
 }
 
@@ -32,7 +32,7 @@ func (s *SphereT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (s *SphereT) SetMaterial(material MaterialT) Object {
-	s.Material = material
+	s.setMaterial(material)
 	return s
 }
 
@@ -0,0 +1,153 @@
+package rtc
+
+import (
+	"math"
+)
+
+// Eye selects one of the two views of a stereo camera.
+type Eye int
+
+const (
+	// LeftEye is the view of the left eye.
+	LeftEye Eye = iota
+	// RightEye is the view of the right eye.
+	RightEye
+)
+
+// StereoLayout selects how the two views of a stereo camera are combined
+// into a single canvas.
+type StereoLayout int
+
+const (
+	// SideBySide places the left eye view to the left of the right eye view.
+	SideBySide StereoLayout = iota
+	// TopBottom places the left eye view above the right eye view.
+	TopBottom
+)
+
+// StereoCameraT renders stereoscopic image pairs for VR headsets and
+// stereo displays.
+type StereoCameraT struct {
+	// Camera is the central camera between the two eyes. Its size,
+	// projection and transform are shared by both views.
+	Camera *CameraT
+	// IPD is the interpupillary distance between the eyes in world units.
+	IPD float64
+	// Convergence is the distance in front of the camera at which the
+	// views of both eyes meet. Objects at this distance appear at the
+	// depth of the screen. Zero (or infinity) uses parallel views.
+	Convergence float64
+	// Layout selects how the two views are combined by Render.
+	Layout StereoLayout
+}
+
+// StereoCamera returns a stereo camera built on the provided camera with
+// the given interpupillary distance and convergence distance.
+//
+// When the camera uses the equirectangular projection, the views are
+// rendered as omni-directional stereo (ODS) panoramas: the eyes turn with
+// each column of the panorama so that the stereo effect holds in every
+// direction.
+func StereoCamera(camera *CameraT, ipd, convergence float64) *StereoCameraT {
+	return &StereoCameraT{
+		Camera:      camera,
+		IPD:         ipd,
+		Convergence: convergence,
+		Layout:      SideBySide,
+	}
+}
+
+// eyeOffset returns the offset of the eye along the camera's X axis,
+// which points to the left.
+func (s *StereoCameraT) eyeOffset(eye Eye) float64 {
+	if eye == LeftEye {
+		return s.IPD / 2
+	}
+	return -s.IPD / 2
+}
+
+// converges reports whether the views of the eyes meet at a finite
+// distance.
+func (s *StereoCameraT) converges() bool {
+	return s.Convergence > 0 && !math.IsInf(s.Convergence, 1)
+}
+
+// EyeCamera returns a camera for the view of the provided eye. The eye is
+// offset from the central camera and turned toward the convergence point.
+// It is not used for omni-directional stereo panoramas.
+func (s *StereoCameraT) EyeCamera(eye Eye) *CameraT {
+	offset := s.eyeOffset(eye)
+	from := Point(offset, 0, 0)
+	to := Point(offset, 0, -1)
+	if s.converges() {
+		to = Point(0, 0, -s.Convergence)
+	}
+
+	c := *s.Camera
+	c.Transform = ViewTransform(from, to, Vector(0, 1, 0)).Mult(s.Camera.Transform)
+	c.cached = false
+	return &c
+}
+
+// RayForPixel returns a ray for the view of the provided eye at the given
+// pixel.
+func (s *StereoCameraT) RayForPixel(eye Eye, px, py int) RayT {
+	if s.Camera.Projection != EquirectangularProjection {
+		return s.EyeCamera(eye).RayForPixel(px, py)
+	}
+	return s.odsRay(eye, px, py)
+}
+
+// odsRay returns the omni-directional stereo ray for the pixel, which
+// starts on the circle traced by the eye as the head turns to face the
+// pixel's longitude.
+func (s *StereoCameraT) odsRay(eye Eye, px, py int) RayT {
+	c := s.Camera
+	c.cache()
+
+	direction := c.equirectangularDirection(px, py)
+	longitude := 2 * math.Pi * ((float64(px)+0.5)/float64(c.HSize) - 0.5)
+	// The camera's X axis (to the left) turned to face the longitude.
+	left := Vector(math.Cos(longitude), 0, -math.Sin(longitude))
+	offset := left.MultScalar(s.eyeOffset(eye))
+
+	if s.converges() {
+		direction = direction.MultScalar(s.Convergence).Sub(offset)
+	}
+
+	origin := c.cachedInv.MultTuple(Point(offset.X(), offset.Y(), offset.Z()))
+	return Ray(origin, c.cachedInv.MultTuple(direction).Normalize())
+}
+
+// RenderEye renders the world as seen by the provided eye.
+func (s *StereoCameraT) RenderEye(world *WorldT, eye Eye) *Canvas {
+	if s.Camera.Projection != EquirectangularProjection {
+		return s.EyeCamera(eye).Render(world)
+	}
+	return s.Camera.render(world, func(x, y int) RayT {
+		return s.odsRay(eye, x, y)
+	})
+}
+
+// Render renders both eyes and combines them into a single canvas using
+// the camera's layout.
+func (s *StereoCameraT) Render(world *WorldT) *Canvas {
+	left := s.RenderEye(world, LeftEye)
+	right := s.RenderEye(world, RightEye)
+
+	w, h := s.Camera.HSize, s.Camera.VSize
+	dx, dy := w, 0
+	canvas := NewCanvas(2*w, h)
+	if s.Layout == TopBottom {
+		dx, dy = 0, h
+		canvas = NewCanvas(w, 2*h)
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			canvas.WritePixel(x, y, left.PixelAt(x, y))
+			canvas.WritePixel(x+dx, y+dy, right.PixelAt(x, y))
+		}
+	}
+	return canvas
+}
@@ -0,0 +1,159 @@
+package rtc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStereoCameraT_RayForPixel(t *testing.T) {
+	sq2 := math.Sqrt2 / 2
+
+	tests := []struct {
+		name          string
+		projection    Projection
+		hsize, vsize  int
+		transform     M4
+		convergence   float64
+		eye           Eye
+		x, y          int
+		wantOrigin    Tuple
+		wantDirection Tuple
+	}{
+		{
+			name:          "parallel left eye",
+			hsize:         201,
+			vsize:         101,
+			transform:     M4Identity(),
+			eye:           LeftEye,
+			x:             100,
+			y:             50,
+			wantOrigin:    Point(1, 0, 0),
+			wantDirection: Vector(0, 0, -1),
+		},
+		{
+			name:          "parallel right eye",
+			hsize:         201,
+			vsize:         101,
+			transform:     M4Identity(),
+			eye:           RightEye,
+			x:             100,
+			y:             50,
+			wantOrigin:    Point(-1, 0, 0),
+			wantDirection: Vector(0, 0, -1),
+		},
+		{
+			name:          "converging left eye",
+			hsize:         201,
+			vsize:         101,
+			transform:     M4Identity(),
+			convergence:   5,
+			eye:           LeftEye,
+			x:             100,
+			y:             50,
+			wantOrigin:    Point(1, 0, 0),
+			wantDirection: Vector(-1, 0, -5).Normalize(),
+		},
+		{
+			name:          "left eye of transformed camera",
+			hsize:         201,
+			vsize:         101,
+			transform:     ViewTransform(Point(0, 0, -5), Point(0, 0, 0), Vector(0, 1, 0)),
+			eye:           LeftEye,
+			x:             100,
+			y:             50,
+			wantOrigin:    Point(-1, 0, -5),
+			wantDirection: Vector(0, 0, 1),
+		},
+		{
+			name:          "omni-directional stereo left eye",
+			projection:    EquirectangularProjection,
+			hsize:         4,
+			vsize:         2,
+			transform:     M4Identity(),
+			eye:           LeftEye,
+			x:             1,
+			y:             0,
+			wantOrigin:    Point(sq2, 0, sq2),
+			wantDirection: Vector(0.5, sq2, -0.5),
+		},
+		{
+			name:          "omni-directional stereo right eye",
+			projection:    EquirectangularProjection,
+			hsize:         4,
+			vsize:         2,
+			transform:     M4Identity(),
+			eye:           RightEye,
+			x:             1,
+			y:             0,
+			wantOrigin:    Point(-sq2, 0, -sq2),
+			wantDirection: Vector(0.5, sq2, -0.5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Camera(tt.hsize, tt.vsize, math.Pi/2)
+			c.Transform = tt.transform
+			c.Projection = tt.projection
+			s := StereoCamera(c, 2, tt.convergence)
+			r := s.RayForPixel(tt.eye, tt.x, tt.y)
+
+			if !r.Origin.Equal(tt.wantOrigin) {
+				t.Errorf("Origin = %v, want %v", r.Origin, tt.wantOrigin)
+			}
+			if !r.Direction.Equal(tt.wantDirection) {
+				t.Errorf("Direction = %v, want %v", r.Direction, tt.wantDirection)
+			}
+		})
+	}
+}
+
+func TestStereoCameraT_Render(t *testing.T) {
+	w := DefaultWorld()
+	c := Camera(11, 7, math.Pi/2)
+	c.Transform = ViewTransform(Point(0, 0, -5), Point(0, 0, 0), Vector(0, 1, 0))
+	c.NumWorkers = 1
+
+	tests := []struct {
+		name             string
+		layout           StereoLayout
+		width, height    int
+		rightDx, rightDy int
+	}{
+		{name: "side by side", layout: SideBySide, width: 22, height: 7, rightDx: 11},
+		{name: "top bottom", layout: TopBottom, width: 11, height: 14, rightDy: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := StereoCamera(c, 0.5, 5)
+			s.Layout = tt.layout
+			canvas := s.Render(w)
+
+			if got := canvas.Bounds().Dx(); got != tt.width {
+				t.Errorf("width = %v, want %v", got, tt.width)
+			}
+			if got := canvas.Bounds().Dy(); got != tt.height {
+				t.Errorf("height = %v, want %v", got, tt.height)
+			}
+
+			left := s.RenderEye(w, LeftEye)
+			right := s.RenderEye(w, RightEye)
+			var differ bool
+			for y := 0; y < 7; y++ {
+				for x := 0; x < 11; x++ {
+					if got, want := canvas.PixelAt(x, y), left.PixelAt(x, y); !got.Equal(want) {
+						t.Fatalf("left pixel (%v,%v) = %v, want %v", x, y, got, want)
+					}
+					if got, want := canvas.PixelAt(x+tt.rightDx, y+tt.rightDy), right.PixelAt(x, y); !got.Equal(want) {
+						t.Fatalf("right pixel (%v,%v) = %v, want %v", x, y, got, want)
+					}
+					differ = differ || !left.PixelAt(x, y).Equal(right.PixelAt(x, y))
+				}
+			}
+			if !differ {
+				t.Errorf("left and right views are identical, want parallax")
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package rtc
+
+// Torus creates a torus at the origin lying in the X-Z plane with its
+// axis on the Y axis. By default, its major radius (from the center of the
+// torus to the center of the tube) is 1 and its minor radius (the radius
+// of the tube) is 0.25.
+// It implements the Object interface.
+func Torus() *TorusT {
+	return &TorusT{
+		Shape:       Shape{Transform: M4Identity(), Material: GetMaterial()},
+		MajorRadius: 1,
+		MinorRadius: 0.25,
+	}
+}
+
+// TorusT represents a torus.
+type TorusT struct {
+	Shape
+	MajorRadius float64
+	MinorRadius float64
+}
+
+var _ Object = &TorusT{}
+
+// SetTransform sets the object's transform 4x4 matrix.
+func (t *TorusT) SetTransform(m M4) Object {
+	t.Transform = m
+	return t
+}
+
+// SetMaterial sets the object's material.
+func (t *TorusT) SetMaterial(material MaterialT) Object {
+	t.setMaterial(material)
+	return t
+}
+
+// SetParent sets the object's parent object.
+func (t *TorusT) SetParent(parent Object) Object {
+	t.Parent = parent
+	return t
+}
+
+// Bounds returns the minimum bounding box of the object in object
+// (untransformed) space.
+func (t *TorusT) Bounds() *BoundsT {
+	r := t.MajorRadius + t.MinorRadius
+	return &BoundsT{
+		Min: Point(-r, -t.MinorRadius, -r),
+		Max: Point(r, t.MinorRadius, r),
+	}
+}
+
+// LocalIntersect returns a slice of IntersectionT values where the
+// transformed (object space) ray intersects the object.
+func (t *TorusT) LocalIntersect(ray RayT) []IntersectionT {
+	// Reject rays that miss the bounding box before solving the quartic.
+	bxs := t.Bounds().LocalIntersect(ray, t)
+	if len(bxs) == 0 {
+		return nil
+	}
+
+	// To keep the quartic well-conditioned, solve it for a unit direction
+	// starting at the point where the ray enters the bounding box, then
+	// convert the roots back to the parameterization of the original ray.
+	length := ray.Direction.Magnitude()
+	t0 := bxs[0].T
+	origin := ray.Position(t0)
+	direction := ray.Direction.DivScalar(length)
+
+	r2 := t.MajorRadius * t.MajorRadius
+	fourR2 := 4 * r2
+	oy, dy := origin.Y(), direction.Y()
+	o := Vector(origin.X(), origin.Y(), origin.Z())
+
+	e := o.Dot(o) - r2 - t.MinorRadius*t.MinorRadius
+	f := o.Dot(direction)
+
+	c4 := 1.0
+	c3 := 4 * f
+	c2 := 2*e + 4*f*f + fourR2*dy*dy
+	c1 := 4*f*e + 2*fourR2*oy*dy
+	c0 := e*e - fourR2*(t.MinorRadius*t.MinorRadius-oy*oy)
+
+	roots := solveQuartic(c4, c3, c2, c1, c0)
+	if len(roots) == 0 {
+		return nil
+	}
+
+	xs := make([]IntersectionT, 0, len(roots))
+	for _, root := range roots {
+		xs = append(xs, Intersection(t0+root/length, t))
+	}
+	return xs
+}
+
+// LocalNormalAt returns the normal vector at the given point of intersection
+// (transformed to object space) with the object.
+func (t *TorusT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
+	x, y, z := objectPoint.X(), objectPoint.Y(), objectPoint.Z()
+	g := x*x + y*y + z*z - t.MajorRadius*t.MajorRadius - t.MinorRadius*t.MinorRadius
+	return Vector(x*g, y*(g+2*t.MajorRadius*t.MajorRadius), z*g)
+}
+
+// Includes returns whether this object includes (or actually is) the
+// other object.
+func (t *TorusT) Includes(other Object) bool {
+	return t == other
+}
@@ -0,0 +1,128 @@
+package rtc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestTorus(t *testing.T) {
+	tr := Torus()
+
+	if got, want := tr.MajorRadius, 1.0; got != want {
+		t.Errorf("Torus.MajorRadius = %v, want %v", got, want)
+	}
+
+	if got, want := tr.MinorRadius, 0.25; got != want {
+		t.Errorf("Torus.MinorRadius = %v, want %v", got, want)
+	}
+
+	b := tr.Bounds()
+	if got, want := b.Min, Point(-1.25, -0.25, -1.25); !got.Equal(want) {
+		t.Errorf("Torus.Bounds.Min = %v, want %v", got, want)
+	}
+	if got, want := b.Max, Point(1.25, 0.25, 1.25); !got.Equal(want) {
+		t.Errorf("Torus.Bounds.Max = %v, want %v", got, want)
+	}
+}
+
+func TestTorusT_LocalIntersect(t *testing.T) {
+	tr := Torus()
+
+	tests := []struct {
+		name string
+		ray  RayT
+		want []float64
+	}{
+		{
+			name: "A ray through the middle of the torus hits the tube four times",
+			ray:  Ray(Point(-5, 0, 0), Vector(1, 0, 0)),
+			want: []float64{3.75, 4.25, 5.75, 6.25},
+		},
+		{
+			name: "A ray with an unnormalized direction",
+			ray:  Ray(Point(-5, 0, 0), Vector(2, 0, 0)),
+			want: []float64{1.875, 2.125, 2.875, 3.125},
+		},
+		{
+			name: "A distant ray is solved robustly",
+			ray:  Ray(Point(-1000, 0, 0.5), Vector(1, 0, 0)),
+			want: []float64{998.85436, 999.44098, 1000.55902, 1001.14564},
+		},
+		{
+			name: "A ray down through the tube hits it twice",
+			ray:  Ray(Point(1, 5, 0), Vector(0, -1, 0)),
+			want: []float64{4.75, 5.25},
+		},
+		{
+			name: "A ray down through the hole misses",
+			ray:  Ray(Point(0, 5, 0), Vector(0, -1, 0)),
+			want: nil,
+		},
+		{
+			name: "A ray above the torus misses",
+			ray:  Ray(Point(-5, 0.5, 0), Vector(1, 0, 0)),
+			want: nil,
+		},
+		{
+			name: "A ray tangent to the top of the tube",
+			ray:  Ray(Point(-5, 0.25, 0), Vector(1, 0, 0)),
+			want: []float64{4, 6},
+		},
+		{
+			name: "A ray from inside the tube",
+			ray:  Ray(Point(1, 0, 0), Vector(0, 0, 1)),
+			want: []float64{-0.75, 0.75},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xs := tr.LocalIntersect(tt.ray)
+			var got []float64
+			for _, x := range xs {
+				if len(got) > 0 && math.Abs(got[len(got)-1]-x.T) < epsilon {
+					continue // tangent rays may report a double root
+				}
+				got = append(got, x.T)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("LocalIntersect = %v, want %v", got, tt.want)
+			}
+			for i, w := range tt.want {
+				if math.Abs(got[i]-w) > epsilon {
+					t.Errorf("LocalIntersect[%v] = %v, want %v", i, got[i], w)
+				}
+				if xs[i].Object != tr {
+					t.Errorf("LocalIntersect[%v].Object = %v, want %v", i, xs[i].Object, tr)
+				}
+			}
+		})
+	}
+}
+
+func TestTorusT_LocalNormalAt(t *testing.T) {
+	tr := Torus()
+	sq2 := math.Sqrt2 / 2
+
+	tests := []struct {
+		point Tuple
+		want  Tuple
+	}{
+		{point: Point(1.25, 0, 0), want: Vector(1, 0, 0)},
+		{point: Point(0.75, 0, 0), want: Vector(-1, 0, 0)},
+		{point: Point(1, 0.25, 0), want: Vector(0, 1, 0)},
+		{point: Point(0, -0.25, 1), want: Vector(0, -1, 0)},
+		{point: Point(0, 0, -1.25), want: Vector(0, 0, -1)},
+		{point: Point(1+0.25*sq2, 0.25*sq2, 0), want: Vector(sq2, sq2, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.point), func(t *testing.T) {
+			if got := tr.LocalNormalAt(tt.point, nil).Normalize(); !got.Equal(tt.want) {
+				t.Errorf("LocalNormalAt(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
@@ -53,7 +53,7 @@ func (t *TriangleT) SetTransform(m M4) Object {
 
 // SetMaterial sets the object's material.
 func (t *TriangleT) SetMaterial(material MaterialT) Object {
-	t.Material = material
+	t.setMaterial(material)
 	return t
 }
 
@@ -106,3 +106,32 @@ func (t *TriangleT) LocalNormalAt(objectPoint Tuple, hit *IntersectionT) Tuple {
 func (t *TriangleT) Includes(other Object) bool {
 	return t == other
 }
+
+// intersectTriangle returns the ray parameter and barycentric (u,v)
+// coordinates where the ray intersects the triangle (p1,p2,p3) using the
+// Möller-Trumbore algorithm. It is used by shapes that store many
+// triangles without creating a TriangleT for each one.
+func intersectTriangle(ray RayT, p1, p2, p3 Tuple) (float64, float64, float64, bool) {
+	e1 := p2.Sub(p1)
+	e2 := p3.Sub(p1)
+	dirCrossE2 := ray.Direction.Cross(e2)
+	det := e1.Dot(dirCrossE2)
+	if math.Abs(det) < epsilon*epsilon {
+		return 0, 0, 0, false
+	}
+
+	f := 1 / det
+	p1ToOrigin := ray.Origin.Sub(p1)
+	u := f * p1ToOrigin.Dot(dirCrossE2)
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+
+	originCrossE1 := p1ToOrigin.Cross(e1)
+	v := f * ray.Direction.Dot(originCrossE1)
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+
+	return f * e2.Dot(originCrossE1), u, v, true
+}
@@ -4,8 +4,25 @@ import "math"
 
 // WorldT represents the world to be rendered.
 type WorldT struct {
-	Objects []Object
-	Lights  []*PointLightT // TODO: Replace with light interfaces.
+	Objects        []Object
+	Lights         []*PointLightT // TODO: Replace with light interfaces.
+	GeometryLights []*GeometryLightT
+	ImageLights    []*ImageLightT
+
+	// Environment provides the color of rays that miss every object.
+	// A nil environment is black.
+	Environment Environment
+
+	// AmbientOcclusion, if set, scales the ambient term of the shading by
+	// the fraction of the hemisphere above each hit that is not occluded.
+	AmbientOcclusion *AmbientOcclusionT
+
+	// Fog is the distance fog of the world, if any.
+	Fog *FogT
+	// VolumeSteps is the number of steps used to integrate the light
+	// scattered within each span of a participating medium (see
+	// MaterialT.Volume). Zero uses a default of 16.
+	VolumeSteps int
 }
 
 // World creates an empty world.
@@ -41,45 +58,138 @@ func (w *WorldT) IntersectWorld(ray RayT) []IntersectionT {
 
 // ShadeHit returns the color (as a Tuple) for the precomputed intersection.
 func (w *WorldT) ShadeHit(comps *Comps, remaining int) Tuple {
-	var result Tuple
+	direct, reflected, refracted := w.shadeHitParts(comps, remaining)
+	return direct.Add(reflected).Add(refracted)
+}
+
+// shadeHitParts returns the direct (emitted and lit), reflected and
+// refracted contributions to the color of the precomputed intersection,
+// which add up to the color returned by ShadeHit.
+func (w *WorldT) shadeHitParts(comps *Comps, remaining int) (Tuple, Tuple, Tuple) {
+	material := comps.Object.GetMaterial()
+
+	ambientScale := 1.0
+	if len(w.Lights) > 0 && material.Ambient != 0 {
+		ambientScale = w.ambientScale(comps)
+	}
+
+	result := material.Emission // Emissive materials glow regardless of lights.
 	for _, light := range w.Lights {
-		shadowed := w.IsShadowed(comps.OverPoint, light)
-		surface := Lighting(comps.Object.GetMaterial(),
+		transmittance := w.transmittanceBetween(comps.OverPoint, light.position, 0)
+		surface := lighting(material,
 			comps.Object,
 			light,
 			comps.Point,
 			comps.EyeVector,
 			comps.NormalVector,
-			shadowed,
+			transmittance,
+			ambientScale,
 		)
+		result = result.Add(surface)
+	}
 
-		reflected := w.ReflectedColor(comps, remaining)
-		refracted := w.RefractedColor(comps, remaining)
+	for _, light := range w.GeometryLights {
+		result = result.Add(w.GeometryLighting(comps, light))
+	}
+
+	for _, light := range w.ImageLights {
+		result = result.Add(w.ImageLighting(comps, light))
+	}
 
-		material := comps.Object.GetMaterial()
-		if material.Reflective > 0 && material.Transparency > 0 {
-			reflectance := comps.Schlick()
-			result = result.Add(surface).Add(reflected.MultScalar(reflectance)).Add(refracted.MultScalar(1 - reflectance))
-		} else {
-			result = result.Add(surface).Add(reflected).Add(refracted)
+	reflected := w.ReflectedColor(comps, remaining)
+	refracted := w.RefractedColor(comps, remaining)
+
+	if material.Reflective > 0 && material.Transparency > 0 {
+		reflectance := comps.Schlick()
+		return result, reflected.MultScalar(reflectance), refracted.MultScalar(1 - reflectance)
+	}
+	return result, reflected, refracted
+}
+
+// GeometryLighting returns the direct illumination (as a Tuple) from the
+// geometry light for the precomputed intersection. Each surface sample of
+// the light is tested separately for shadows, producing soft shadows, and
+// is attenuated by the participating media in between.
+// An emitter does not illuminate itself.
+func (w *WorldT) GeometryLighting(comps *Comps, light *GeometryLightT) Tuple {
+	if light.Object.Includes(comps.Object) {
+		return Color(0, 0, 0)
+	}
+
+	material := comps.Object.GetMaterial()
+	color := surfaceColor(material, comps.Object, comps.Point)
+
+	result := Color(0, 0, 0)
+	for _, sample := range light.samples() {
+		transmittance := w.transmittanceBetween(comps.OverPoint, sample.position, epsilon)
+		if transmittance.Equal(Color(0, 0, 0)) {
+			continue
 		}
+		intensity := sample.intensity.HadamardProduct(transmittance)
+		result = result.Add(directLighting(material, color, sample.position, intensity, comps.Point, comps.EyeVector, comps.NormalVector))
+	}
+	return result
+}
+
+// ImageLighting returns the direct illumination (as a Tuple) from the
+// image light for the precomputed intersection. A shadow ray is cast in
+// the direction of each sample, and any object it hits blocks the light.
+func (w *WorldT) ImageLighting(comps *Comps, light *ImageLightT) Tuple {
+	material := comps.Object.GetMaterial()
+	color := surfaceColor(material, comps.Object, comps.Point)
+
+	result := Color(0, 0, 0)
+	for _, sample := range light.samples() {
+		if sample.direction.Dot(comps.NormalVector) < 0 || w.isShadowedInDirection(comps.OverPoint, sample.direction) {
+			continue
+		}
+		result = result.Add(directionalLighting(material, color, sample.direction, sample.intensity, comps.EyeVector, comps.NormalVector))
 	}
 	return result
 }
 
 // ColorAt returns the color (as a Tuple) when casting the given ray.
 func (w *WorldT) ColorAt(ray RayT, remaining int) Tuple {
+	color, _ := w.colorAtWithDistance(ray, remaining)
+	return color
+}
+
+// colorAtWithDistance returns the color (as a Tuple) when casting the given
+// ray along with the distance to the hit (or +Inf if nothing was hit).
+func (w *WorldT) colorAtWithDistance(ray RayT, remaining int) (Tuple, float64) {
 	xs := w.IntersectWorld(ray)
-	hit := Hit(xs)
-	if hit == nil {
-		return Color(0, 0, 0)
+	hit := surfaceHit(xs)
+
+	color, distance := w.environmentColor(ray.Direction), math.Inf(1)
+	if hit != nil {
+		comps := hit.PrepareComputations(ray, xs)
+		color, distance = w.ShadeHit(comps, remaining), hit.T
+	}
+
+	return w.applyAtmosphere(ray, xs, distance, color), distance
+}
+
+// applyAtmosphere returns the color seen at the given distance along the
+// ray through the participating media and fog of the world.
+func (w *WorldT) applyAtmosphere(ray RayT, xs []IntersectionT, distance float64, color Tuple) Tuple {
+	color = w.applyMedia(ray, xs, distance, color)
+	if w.Fog != nil {
+		color = w.Fog.Apply(color, distance)
 	}
+	return color
+}
 
-	comps := hit.PrepareComputations(ray, xs)
-	return w.ShadeHit(comps, remaining)
+// environmentColor returns the color of the environment in the provided
+// direction.
+func (w *WorldT) environmentColor(direction Tuple) Tuple {
+	if w.Environment == nil {
+		return Color(0, 0, 0)
+	}
+	return w.Environment.ColorAt(direction.Normalize())
 }
 
 // IsShadowed determines if the provided point is in a shadow for the given light.
+// The boundaries of participating media do not cast shadows.
 func (w *WorldT) IsShadowed(point Tuple, light *PointLightT) bool {
 	v := light.position.Sub(point)
 	distance := v.Magnitude()
@@ -89,11 +199,18 @@ func (w *WorldT) IsShadowed(point Tuple, light *PointLightT) bool {
 
 	intersections := w.IntersectWorld(r)
 
-	h := Hit(intersections)
+	h := surfaceHit(intersections)
 
 	return h != nil && h.T < distance
 }
 
+// isShadowedInDirection determines if any object (other than the
+// boundaries of participating media) lies in the provided direction from
+// the point.
+func (w *WorldT) isShadowedInDirection(point, direction Tuple) bool {
+	return surfaceHit(w.IntersectWorld(Ray(point, direction))) != nil
+}
+
 // ReflectedColor returns the reflected color for the precomputed intersection.
 func (w *WorldT) ReflectedColor(comps *Comps, remaining int) Tuple {
 	if remaining < 1 || comps.Object.GetMaterial().Reflective == 0 {
@@ -123,7 +240,10 @@ func (w *WorldT) RefractedColor(comps *Comps, remaining int) Tuple {
 	direction := comps.NormalVector.MultScalar(nRatio*cosI - cosT).Sub(comps.EyeVector.MultScalar(nRatio))
 
 	refractedRay := Ray(comps.UnderPoint, direction)
-	color := w.ColorAt(refractedRay, remaining-1)
+	color, distance := w.colorAtWithDistance(refractedRay, remaining-1)
+	if comps.Medium != nil {
+		color = color.HadamardProduct(comps.Medium.GetMaterial().Transmittance(distance))
+	}
 	return color.MultScalar(comps.Object.GetMaterial().Transparency)
 }
 
@@ -136,6 +256,16 @@ func WorldToObject(object Object, point Tuple) Tuple {
 	return object.GetTransform().Inverse().MultTuple(point)
 }
 
+// ObjectToWorld converts an object-space point to world space, taking into
+// account all the parents of the object.
+func ObjectToWorld(object Object, point Tuple) Tuple {
+	point = object.GetTransform().MultTuple(point)
+	if p := object.GetParent(); p != nil {
+		point = ObjectToWorld(p, point)
+	}
+	return point
+}
+
 // NormalToWorld converts an object-space normal to world space, taking into
 // account all the parents of the object.
 func NormalToWorld(object Object, normal Tuple) Tuple {
@@ -197,6 +197,21 @@ func TestWorldT_IsShadowed(t *testing.T) {
 	}
 }
 
+func TestWorldT_IsShadowed_NearTheLight(t *testing.T) {
+	// A wall just short of the point light still casts a shadow. Only the
+	// samples of geometry lights ignore hits right at their position.
+	wall := Plane()
+	wall.SetTransform(Translation(0, 0, -10+epsilon/2).Mult(RotationX(math.Pi / 2)))
+
+	w := World()
+	w.Objects = []Object{wall}
+	w.Lights = []*PointLightT{PointLight(Point(0, 0, -10), Color(1, 1, 1))}
+
+	if !w.IsShadowed(Point(0, 0, 0), w.Lights[0]) {
+		t.Error("IsShadowed = false, want true")
+	}
+}
+
 func TestWorldT_ReflectedColor(t *testing.T) {
 	w := DefaultWorld()
 	r := Ray(Point(0, 0, 0), Vector(0, 0, 1))
@@ -247,6 +262,45 @@ func TestWorldT_ShadeHit_WithReflectiveMaterial(t *testing.T) {
 	}
 }
 
+func TestWorldT_ShadeHit_ReflectsOncePerHit(t *testing.T) {
+	sq2 := math.Sqrt2 / 2
+	floor := Plane()
+	floor.GetMaterial().Reflective = 0.5
+	floor.SetTransform(Translation(0, -1, 0))
+
+	// The reflected ceiling only glows, so its color does not depend on lights.
+	ceiling := Plane()
+	ceiling.GetMaterial().Emission = Color(0.2, 0.4, 0.6)
+	ceiling.GetMaterial().Ambient = 0
+	ceiling.GetMaterial().Diffuse = 0
+	ceiling.GetMaterial().Specular = 0
+	ceiling.SetTransform(Translation(0, 1, 0))
+
+	w := World()
+	w.Objects = []Object{floor, ceiling}
+	w.Lights = []*PointLightT{PointLight(Point(-10, 0, -10), Color(1, 1, 1))}
+
+	r := Ray(Point(0, 0, -3), Vector(0, -sq2, sq2))
+	i := Intersection(math.Sqrt2, floor)
+	comps := i.PrepareComputations(r, []IntersectionT{i})
+
+	reflected := Color(0.1, 0.2, 0.3)
+	oneLight := w.ShadeHit(comps, maxReflections)
+
+	// A second light at the same position doubles the direct lighting but not
+	// the reflection.
+	w.Lights = append(w.Lights, w.Lights[0])
+	if got, want := w.ShadeHit(comps, maxReflections), oneLight.MultScalar(2).Sub(reflected); !got.Equal(want) {
+		t.Errorf("ShadeHit with two lights = %v, want %v", got, want)
+	}
+
+	// Without any point lights, the surface still reflects.
+	w.Lights = nil
+	if got, want := w.ShadeHit(comps, maxReflections), reflected; !got.Equal(want) {
+		t.Errorf("ShadeHit without lights = %v, want %v", got, want)
+	}
+}
+
 func TestWorldT_ShadeHit_WithMutuallyReflectiveSurfaces(t *testing.T) {
 	w := World()
 	w.Lights = []*PointLightT{PointLight(Point(0, 0, 0), Color(1, 1, 1))}
@@ -398,6 +452,68 @@ func TestWorldT_RefractedColor_WithRefractedColor(t *testing.T) {
 	}
 }
 
+func TestWorldT_RefractedColor_WithAbsorption(t *testing.T) {
+	w := World()
+	w.Lights = []*PointLightT{PointLight(Point(-10, 10, -10), Color(1, 1, 1))}
+
+	floor := Plane()
+	floor.SetTransform(Translation(0, 0, 10).Mult(RotationX(math.Pi / 2)))
+	floor.GetMaterial().Ambient = 1
+	floor.GetMaterial().Diffuse = 0
+	floor.GetMaterial().Specular = 0
+
+	ball := GlassSphere()
+	ball.GetMaterial().Ambient = 0
+	ball.GetMaterial().Diffuse = 0
+	ball.GetMaterial().Specular = 0
+	w.Objects = []Object{floor, ball}
+
+	r := Ray(Point(0, 0, -5), Vector(0, 0, 1))
+	xs := Intersections(Intersection(4, ball), Intersection(6, ball))
+
+	comps := xs[0].PrepareComputations(r, xs)
+	if comps.Medium != ball {
+		t.Fatalf("comps.Medium = %v, want %v", comps.Medium, ball)
+	}
+
+	if got, want := w.RefractedColor(comps, 5), Color(1, 1, 1); !got.Equal(want) {
+		t.Errorf("w.RefractedColor without absorption = %v, want %v", got, want)
+	}
+
+	ball.GetMaterial().AbsorptionColor = Color(1, 0.5, 0)
+	ball.GetMaterial().AbsorptionDensity = 1
+	if got, want := w.RefractedColor(comps, 5), Color(1, math.Exp(-1), math.Exp(-2)); !got.Equal(want) {
+		t.Errorf("w.RefractedColor with absorption = %v, want %v", got, want)
+	}
+}
+
+func TestWorldT_RefractedColor_WithAbsorption_IntoNothing(t *testing.T) {
+	w := World()
+	w.Environment = SolidEnvironment(Color(1, 1, 1))
+
+	pane := Plane()
+	pane.GetMaterial().Ambient = 0
+	pane.GetMaterial().Diffuse = 0
+	pane.GetMaterial().Specular = 0
+	pane.GetMaterial().Transparency = 1
+	pane.GetMaterial().AbsorptionColor = Color(1, 0.5, 0.5)
+	pane.GetMaterial().AbsorptionDensity = 1
+	w.Objects = []Object{pane}
+
+	// The refracted ray never leaves the plane's half-space, so it travels
+	// an infinite distance through the absorbing material.
+	r := Ray(Point(0, 1, 0), Vector(0, -1, 0))
+	xs := Intersections(Intersection(1, pane))
+	comps := xs[0].PrepareComputations(r, xs)
+
+	if got, want := w.RefractedColor(comps, 5), Color(1, 0, 0); !got.Equal(want) {
+		t.Errorf("w.RefractedColor = %v, want %v", got, want)
+	}
+	if got, want := w.ColorAt(r, 5), Color(1, 0, 0); !got.Equal(want) {
+		t.Errorf("w.ColorAt = %v, want %v", got, want)
+	}
+}
+
 func TestWorldToObject(t *testing.T) {
 	s := Sphere().SetTransform(Translation(5, 0, 0))
 	g2 := Group(s).SetTransform(Scaling(2, 2, 2))
@@ -1,6 +1,7 @@
 package yaml
 
 import (
+	"log"
 	"math"
 
 	"github.com/gmlewis/rtc/rtc"
@@ -39,6 +40,16 @@ func (y *YAMLFile) Camera(xsize, ysize *int, fov *float64) *rtc.CameraT {
 			to := rtc.Vector(item.To[0], item.To[1], item.To[2])
 			up := rtc.Vector(item.Up[0], item.Up[1], item.Up[2])
 			camera.Transform = rtc.ViewTransform(from, to, up)
+			if item.Projection != nil {
+				projection, err := rtc.ParseProjection(*item.Projection)
+				if err != nil {
+					log.Printf("camera: %v", err)
+				}
+				camera.Projection = projection
+			}
+			if item.OrthographicWidth != nil {
+				camera.OrthographicWidth = *item.OrthographicWidth
+			}
 			return camera
 		}
 	}
@@ -52,3 +52,29 @@ func TestCamera(t *testing.T) {
 		t.Errorf("c.Transform[3] = %v, want %v", got, want)
 	}
 }
+
+func TestCamera_Projection(t *testing.T) {
+	const src = `- add: camera
+  width: 40
+  height: 20
+  field-of-view: 1
+  from: [0, 0, -5]
+  to: [0, 0, 0]
+  up: [0, 1, 0]
+  projection: orthographic
+  orthographic-width: 12.5
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := y.Camera(nil, nil, nil)
+	if got, want := c.Projection, rtc.OrthographicProjection; got != want {
+		t.Errorf("c.Projection = %v, want %v", got, want)
+	}
+	if got, want := c.OrthographicWidth, 12.5; got != want {
+		t.Errorf("c.OrthographicWidth = %v, want %v", got, want)
+	}
+}
@@ -10,52 +10,68 @@ import (
 
 // ToYAML converts the scene back to a YAML file.
 func (y *YAMLFile) ToYAML() ([]byte, error) {
-	for i, item := range y.Items {
-		if v := item.Material; v != nil {
-			var buf []byte
+	for i := range y.Items {
+		if err := encodeItem(&y.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return yaml.Marshal(y.Items)
+}
+
+// encodeItem converts the expanded material and transform of the item and
+// its children back to raw messages.
+func encodeItem(item *Item) error {
+	if v := item.Material; v != nil {
+		var buf []byte
+		if v.NamedItem != nil {
+			buf = []byte(fmt.Sprintf("%q", *v.NamedItem))
+		} else {
+			var err error
+			buf, err = json.Marshal(v)
+			if err != nil {
+				return err
+			}
+		}
+		if item.Define != nil {
+			item.RawValue = buf
+		} else {
+			item.RawMaterial = buf
+		}
+		item.Material = nil
+	}
+
+	if item.Transform != nil {
+		var parts []string
+
+		for _, v := range item.Transform {
 			if v.NamedItem != nil {
-				buf = []byte(fmt.Sprintf("%q", *v.NamedItem))
-			} else {
-				var err error
-				buf, err = json.Marshal(v)
-				if err != nil {
-					return nil, err
-				}
+				parts = append(parts, fmt.Sprintf("%q", *v.NamedItem))
+				continue
 			}
-			if y.Items[i].Define != nil {
-				y.Items[i].RawValue = buf
-			} else {
-				y.Items[i].RawMaterial = buf
+			if v.Type == nil {
+				return fmt.Errorf("expected NamedItem or Type/Args in ValueArray, got %#v", *v)
 			}
-			y.Items[i].Material = nil
+			var p2 []string
+			for _, arg := range v.Args {
+				p2 = append(p2, fmt.Sprintf("%v", arg))
+			}
+			parts = append(parts, fmt.Sprintf("[%q,%v]", *v.Type, strings.Join(p2, ",")))
 		}
 
-		if item.Transform != nil {
-			var parts []string
-
-			for _, v := range item.Transform {
-				if v.NamedItem != nil {
-					parts = append(parts, fmt.Sprintf("%q", *v.NamedItem))
-					continue
-				}
-				if v.Type == nil {
-					return nil, fmt.Errorf("expected NamedItem or Type/Args in ValueArray, got %#v", *v)
-				}
-				var p2 []string
-				for _, arg := range v.Args {
-					p2 = append(p2, fmt.Sprintf("%v", arg))
-				}
-				parts = append(parts, fmt.Sprintf("[%q,%v]", *v.Type, strings.Join(p2, ",")))
-			}
+		if item.Define != nil {
+			item.RawValue = []byte(fmt.Sprintf("[%v]", strings.Join(parts, ",")))
+		} else {
+			item.RawTransform = []byte(fmt.Sprintf("[%v]", strings.Join(parts, ",")))
+		}
+		item.Transform = nil
+	}
 
-			if y.Items[i].Define != nil {
-				y.Items[i].RawValue = []byte(fmt.Sprintf("[%v]", strings.Join(parts, ",")))
-			} else {
-				y.Items[i].RawTransform = []byte(fmt.Sprintf("[%v]", strings.Join(parts, ",")))
-			}
-			y.Items[i].Transform = nil
+	for _, child := range item.Children {
+		if err := encodeItem(child); err != nil {
+			return err
 		}
 	}
 
-	return yaml.Marshal(y.Items)
+	return nil
 }
@@ -31,3 +31,34 @@ func TestToYAML(t *testing.T) {
 		}
 	}
 }
+
+func TestToYAML_GroupChildren(t *testing.T) {
+	const src = `- add: group
+  children:
+  - add: sphere
+    material:
+      color:
+      - 1
+      - 0
+      - 0
+    transform:
+    - - translate
+      - 1
+      - 2
+      - 3
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := y.ToYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(buf); got != src {
+		t.Errorf("ToYAML =\n%v\nwant\n%v", got, src)
+	}
+}
@@ -44,57 +44,74 @@ func Parse(r io.Reader) (*YAMLFile, error) {
 		return nil, err
 	}
 
-	for i, item := range y.Items {
+	for i := range y.Items {
+		item := &y.Items[i]
 		if item.Define != nil {
-			y.DefinedItems[*item.Define] = &y.Items[i]
+			y.DefinedItems[*item.Define] = item
 		}
+		if err := expandItem(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return y, nil
+}
 
-		if item.RawValue != nil {
-			switch []byte(item.RawValue)[0] {
-			case '[':
-				y.Items[i].Transform, err = parseTransform(item.RawValue)
-				if err != nil {
-					return nil, err
-				}
-				y.Items[i].RawValue = nil
-			case '{':
-				if err := json.Unmarshal(item.RawValue, &y.Items[i].Material); err != nil {
-					return nil, err
-				}
-				y.Items[i].RawValue = nil
-			default:
-				return nil, fmt.Errorf("unknown item.Value: %s", item.RawValue)
+// expandItem expands the raw messages of the item and its children.
+func expandItem(item *Item) error {
+	var err error
+
+	if item.RawValue != nil {
+		switch []byte(item.RawValue)[0] {
+		case '[':
+			item.Transform, err = parseTransform(item.RawValue)
+			if err != nil {
+				return err
 			}
+			item.RawValue = nil
+		case '{':
+			if err := json.Unmarshal(item.RawValue, &item.Material); err != nil {
+				return err
+			}
+			item.RawValue = nil
+		default:
+			return fmt.Errorf("unknown item.Value: %s", item.RawValue)
 		}
+	}
 
-		if item.RawMaterial != nil {
-			material := &YAMLMaterial{}
-			switch []byte(item.RawMaterial)[0] {
-			case '"':
-				namedItem := strings.Trim(string(item.RawMaterial), "\"")
-				material.NamedItem = &namedItem
-			case '{':
-				if err := json.Unmarshal(item.RawMaterial, &material); err != nil {
-					return nil, err
-				}
+	if item.RawMaterial != nil {
+		material := &YAMLMaterial{}
+		switch []byte(item.RawMaterial)[0] {
+		case '"':
+			namedItem := strings.Trim(string(item.RawMaterial), "\"")
+			material.NamedItem = &namedItem
+		case '{':
+			if err := json.Unmarshal(item.RawMaterial, &material); err != nil {
+				return err
 			}
-			y.Items[i].RawMaterial = nil
-			y.Items[i].Material = material
 		}
+		item.RawMaterial = nil
+		item.Material = material
+	}
 
-		if item.RawTransform != nil {
-			if []byte(item.RawTransform)[0] != '[' {
-				return nil, fmt.Errorf("expected RawTransform to start with '[', got %s", item.RawTransform)
-			}
-			y.Items[i].Transform, err = parseTransform(item.RawTransform)
-			if err != nil {
-				return nil, err
-			}
-			y.Items[i].RawTransform = nil
+	if item.RawTransform != nil {
+		if []byte(item.RawTransform)[0] != '[' {
+			return fmt.Errorf("expected RawTransform to start with '[', got %s", item.RawTransform)
+		}
+		item.Transform, err = parseTransform(item.RawTransform)
+		if err != nil {
+			return err
 		}
+		item.RawTransform = nil
 	}
 
-	return y, nil
+	for _, child := range item.Children {
+		if err := expandItem(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func parseTransform(v json.RawMessage) ([]*YAMLTransform, error) {
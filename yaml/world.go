@@ -8,7 +8,8 @@ import (
 
 // AddToWorld adds the yaml data to the RTC world as is (no added groups).
 func (y *YAMLFile) AddToWorld(w *rtc.WorldT) {
-	for _, item := range y.Items {
+	for i := range y.Items {
+		item := &y.Items[i]
 		if item.Define != nil {
 			continue
 		}
@@ -17,19 +18,71 @@ func (y *YAMLFile) AddToWorld(w *rtc.WorldT) {
 		case "camera":
 			continue
 		case "light":
-			y.addLight(&item, w)
-		case "plane":
-			y.addPlane(&item, w)
-		case "sphere":
-			y.addSphere(&item, w)
-		case "cube":
-			y.addCube(&item, w)
+			y.addLight(item, w)
+		case "fog":
+			y.addFog(item, w)
 		default:
-			log.Printf("unknown YAML item: %v", item)
+			n := len(w.GeometryLights)
+			if object := y.getObject(item, w); object != nil {
+				w.Objects = append(w.Objects, object)
+			}
+			// Emission is only known once inherited materials are resolved.
+			w.GeometryLights = append(w.GeometryLights[:n], emissiveLights(w.GeometryLights[n:])...)
 		}
 	}
 }
 
+// emissiveLights returns the geometry lights whose objects emit light,
+// ignoring (and logging) the others, which would only darken the scene.
+func emissiveLights(lights []*rtc.GeometryLightT) []*rtc.GeometryLightT {
+	var result []*rtc.GeometryLightT
+	for _, light := range lights {
+		if e := light.Object.GetMaterial().Emission; e.Red() <= 0 && e.Green() <= 0 && e.Blue() <= 0 {
+			log.Printf("Geometry light on %T without emission, ignoring.", light.Object)
+			continue
+		}
+		result = append(result, light)
+	}
+	return result
+}
+
+// getObject returns the object described by the item, or nil if the item
+// is unknown.
+func (y *YAMLFile) getObject(item *Item, w *rtc.WorldT) rtc.Object {
+	if item.Add == nil {
+		log.Printf("expected add, got YAML item: %v", item)
+		return nil
+	}
+
+	switch *item.Add {
+	case "group":
+		return y.getGroup(item, w)
+	case "plane":
+		return y.getPlane(item, w)
+	case "sphere":
+		return y.getSphere(item, w)
+	case "cube":
+		return y.getCube(item, w)
+	case "cylinder":
+		return y.getCylinder(item, w)
+	case "cone":
+		return y.getCone(item, w)
+	case "torus":
+		return y.getTorus(item, w)
+	case "disk":
+		return y.getDisk(item, w)
+	case "annulus":
+		return y.getAnnulus(item, w)
+	case "quad":
+		return y.getQuad(item, w)
+	case "blob":
+		return y.getBlob(item, w)
+	default:
+		log.Printf("unknown YAML item: %v", item)
+		return nil
+	}
+}
+
 func (y *YAMLFile) addLight(item *Item, w *rtc.WorldT) {
 	position := rtc.Point(item.At[0], item.At[1], item.At[2])
 	intensity := rtc.Color(item.Intensity[0], item.Intensity[1], item.Intensity[2])
@@ -37,25 +90,219 @@ func (y *YAMLFile) addLight(item *Item, w *rtc.WorldT) {
 	w.Lights = append(w.Lights, light)
 }
 
-func (y *YAMLFile) addPlane(item *Item, w *rtc.WorldT) {
+// addFog sets the distance fog of the world. The mode is either linear
+// (the default) or exponential.
+func (y *YAMLFile) addFog(item *Item, w *rtc.WorldT) {
+	color := rtc.Color(1, 1, 1)
+	if len(item.Color) == 3 {
+		color = rtc.Color(item.Color[0], item.Color[1], item.Color[2])
+	}
+
+	fog := rtc.LinearFog(color, 0, 100)
+	if item.Mode != nil {
+		switch *item.Mode {
+		case "linear":
+		case "exponential":
+			fog = rtc.ExponentialFog(color, 0.1)
+		default:
+			log.Printf("Unknown fog mode %q, using linear.", *item.Mode)
+		}
+	}
+	setFloat(&fog.Start, item.Start)
+	setFloat(&fog.End, item.End)
+	setFloat(&fog.Density, item.Density)
+	w.Fog = fog
+}
+
+// getGroup returns a group of the item's children. Children without a
+// material of their own inherit the material of the nearest enclosing
+// group that has one.
+func (y *YAMLFile) getGroup(item *Item, w *rtc.WorldT) rtc.Object {
+	group := rtc.Group()
+	for _, childItem := range item.Children {
+		child := y.getObject(childItem, w)
+		if child == nil {
+			continue
+		}
+		if childItem.Material == nil {
+			rtc.InheritMaterial(child)
+		}
+		group.AddChild(child)
+	}
+	if item.Material != nil {
+		y.addMaterial(item, group)
+	}
+	y.setTransform(item, group)
+	return group
+}
+
+func (y *YAMLFile) getPlane(item *Item, w *rtc.WorldT) rtc.Object {
 	object := rtc.Plane()
 	y.addMaterial(item, object)
 	y.setTransform(item, object)
-	w.Objects = append(w.Objects, object)
+	return object
 }
 
-func (y *YAMLFile) addSphere(item *Item, w *rtc.WorldT) {
+func (y *YAMLFile) getSphere(item *Item, w *rtc.WorldT) rtc.Object {
 	object := rtc.Sphere()
 	y.addMaterial(item, object)
 	y.setTransform(item, object)
-	w.Objects = append(w.Objects, object)
+	y.addGeometryLight(item, object, w)
+	return object
 }
 
-func (y *YAMLFile) addCube(item *Item, w *rtc.WorldT) {
+func (y *YAMLFile) getCube(item *Item, w *rtc.WorldT) rtc.Object {
 	object := rtc.Cube()
 	y.addMaterial(item, object)
 	y.setTransform(item, object)
-	w.Objects = append(w.Objects, object)
+	y.addGeometryLight(item, object, w)
+	return object
+}
+
+func (y *YAMLFile) getCylinder(item *Item, w *rtc.WorldT) rtc.Object {
+	object := rtc.Cylinder()
+	setFloat(&object.Minimum, item.Minimum)
+	setFloat(&object.Maximum, item.Maximum)
+	setBool(&object.Closed, item.Closed)
+	setFloat(&object.BottomRadius, item.BottomRadius)
+	setFloat(&object.TopRadius, item.TopRadius)
+	setBool(&object.BottomClosed, item.BottomClosed)
+	setBool(&object.TopClosed, item.TopClosed)
+	setFloat(&object.StartAngle, item.StartAngle)
+	setFloat(&object.EndAngle, item.EndAngle)
+	y.addMaterial(item, object)
+	y.setTransform(item, object)
+	return object
+}
+
+func (y *YAMLFile) getCone(item *Item, w *rtc.WorldT) rtc.Object {
+	object := rtc.Cone()
+	setFloat(&object.Minimum, item.Minimum)
+	setFloat(&object.Maximum, item.Maximum)
+	setBool(&object.Closed, item.Closed)
+	setFloat(&object.BottomRadius, item.BottomRadius)
+	setFloat(&object.TopRadius, item.TopRadius)
+	setBool(&object.BottomClosed, item.BottomClosed)
+	setBool(&object.TopClosed, item.TopClosed)
+	setFloat(&object.StartAngle, item.StartAngle)
+	setFloat(&object.EndAngle, item.EndAngle)
+	y.addMaterial(item, object)
+	y.setTransform(item, object)
+	return object
+}
+
+// setFloat sets the value if it is provided.
+func setFloat(dst *float64, v *float64) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+// setBool sets the value if it is provided.
+func setBool(dst *bool, v *bool) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func (y *YAMLFile) getTorus(item *Item, w *rtc.WorldT) rtc.Object {
+	object := rtc.Torus()
+	if item.MajorRadius != nil {
+		object.MajorRadius = *item.MajorRadius
+	}
+	if item.MinorRadius != nil {
+		object.MinorRadius = *item.MinorRadius
+	}
+	y.addMaterial(item, object)
+	y.setTransform(item, object)
+	return object
+}
+
+func (y *YAMLFile) getDisk(item *Item, w *rtc.WorldT) rtc.Object {
+	object := rtc.Disk()
+	if item.Radius != nil {
+		object.Radius = *item.Radius
+	}
+	y.addMaterial(item, object)
+	y.setTransform(item, object)
+	y.addGeometryLight(item, object, w)
+	return object
+}
+
+func (y *YAMLFile) getAnnulus(item *Item, w *rtc.WorldT) rtc.Object {
+	object := rtc.Annulus()
+	if item.InnerRadius != nil {
+		object.InnerRadius = *item.InnerRadius
+	}
+	if item.OuterRadius != nil {
+		object.OuterRadius = *item.OuterRadius
+	}
+	y.addMaterial(item, object)
+	y.setTransform(item, object)
+	y.addGeometryLight(item, object, w)
+	return object
+}
+
+func (y *YAMLFile) getQuad(item *Item, w *rtc.WorldT) rtc.Object {
+	corner, uvec, vvec := rtc.Point(-1, 0, -1), rtc.Vector(2, 0, 0), rtc.Vector(0, 0, 2)
+	if len(item.Corner) == 3 {
+		corner = rtc.Point(item.Corner[0], item.Corner[1], item.Corner[2])
+	}
+	if len(item.UVec) == 3 {
+		uvec = rtc.Vector(item.UVec[0], item.UVec[1], item.UVec[2])
+	}
+	if len(item.VVec) == 3 {
+		vvec = rtc.Vector(item.VVec[0], item.VVec[1], item.VVec[2])
+	}
+	object := rtc.Quad(corner, uvec, vvec)
+	y.addMaterial(item, object)
+	y.setTransform(item, object)
+	y.addGeometryLight(item, object, w)
+	return object
+}
+
+func (y *YAMLFile) getBlob(item *Item, w *rtc.WorldT) rtc.Object {
+	threshold := 0.5
+	if item.Threshold != nil {
+		threshold = *item.Threshold
+	}
+	object := rtc.Blob(threshold)
+	for _, c := range item.Components {
+		component := rtc.BlobComponent{Center: rtc.Point(0, 0, 0), Radius: 1, Strength: 1}
+		if len(c.Center) == 3 {
+			component.Center = rtc.Point(c.Center[0], c.Center[1], c.Center[2])
+		}
+		if c.Radius != nil {
+			component.Radius = *c.Radius
+		}
+		if c.Strength != nil {
+			component.Strength = *c.Strength
+		}
+		object.Components = append(object.Components, component)
+	}
+	y.addMaterial(item, object)
+	y.setTransform(item, object)
+	return object
+}
+
+// addGeometryLight registers the emissive object as a geometry light
+// if the item specifies its number of samples.
+func (y *YAMLFile) addGeometryLight(item *Item, o rtc.Object, w *rtc.WorldT) {
+	if item.USteps == nil && item.VSteps == nil {
+		return
+	}
+	usteps, vsteps := 1, 1
+	if item.USteps != nil {
+		usteps = *item.USteps
+	}
+	if item.VSteps != nil {
+		vsteps = *item.VSteps
+	}
+	light := rtc.GeometryLight(o, usteps, vsteps)
+	if item.Jitter != nil {
+		light.Jitter = *item.Jitter
+	}
+	w.GeometryLights = append(w.GeometryLights, light)
 }
 
 func (y *YAMLFile) addMaterial(item *Item, o rtc.Object) {
@@ -104,9 +351,53 @@ func (y *YAMLFile) getMaterial(item *Item) rtc.MaterialT {
 	if m.Transparency != nil {
 		material.Transparency = *m.Transparency
 	}
+	if len(m.AbsorptionColor) == 3 {
+		material.AbsorptionColor = rtc.Color(m.AbsorptionColor[0], m.AbsorptionColor[1], m.AbsorptionColor[2])
+	}
+	if m.AbsorptionDensity != nil {
+		material.AbsorptionDensity = *m.AbsorptionDensity
+	}
+	if m.Volume != nil {
+		material.Volume = *m.Volume
+	}
+	if len(m.VolumeScattering) == 3 {
+		material.VolumeScattering = rtc.Color(m.VolumeScattering[0], m.VolumeScattering[1], m.VolumeScattering[2])
+	}
+	if len(m.VolumeAbsorption) == 3 {
+		material.VolumeAbsorption = rtc.Color(m.VolumeAbsorption[0], m.VolumeAbsorption[1], m.VolumeAbsorption[2])
+	}
+	if m.VolumeAnisotropy != nil {
+		material.VolumeAnisotropy = *m.VolumeAnisotropy
+	}
+	if len(m.Emission) == 3 {
+		material.Emission = rtc.Color(m.Emission[0], m.Emission[1], m.Emission[2])
+	}
+	if m.Model != nil {
+		material.Model = getShadingModel(*m.Model)
+	}
+	if m.Metallic != nil {
+		material.Metallic = *m.Metallic
+	}
+	if m.Roughness != nil {
+		material.Roughness = *m.Roughness
+	}
 	return material
 }
 
+func getShadingModel(name string) rtc.ShadingModel {
+	switch name {
+	case "phong":
+		return rtc.Phong()
+	case "blinn-phong":
+		return rtc.BlinnPhong()
+	case "cook-torrance":
+		return rtc.CookTorrance()
+	default:
+		log.Printf("Unknown shading model %q, using phong.", name)
+		return rtc.Phong()
+	}
+}
+
 func (y *YAMLFile) getMaterialByName(name string) rtc.MaterialT {
 	item, ok := y.DefinedItems[name]
 	if !ok {
@@ -0,0 +1,443 @@
+package yaml
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/gmlewis/rtc/rtc"
+)
+
+func TestGetMaterial_ShadingModel(t *testing.T) {
+	const src = `- define: metal
+  value:
+    model: cook-torrance
+    metallic: 1
+    roughness: 0.25
+- define: shiny
+  value:
+    model: blinn-phong
+- add: sphere
+  material: metal
+- add: sphere
+  material: shiny
+- add: sphere
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+	if got, want := len(w.Objects), 3; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+
+	m := w.Objects[0].GetMaterial()
+	if _, ok := m.Model.(*rtc.CookTorranceT); !ok {
+		t.Errorf("metal Model = %T, want *rtc.CookTorranceT", m.Model)
+	}
+	if got, want := m.Metallic, 1.0; got != want {
+		t.Errorf("metal Metallic = %v, want %v", got, want)
+	}
+	if got, want := m.Roughness, 0.25; got != want {
+		t.Errorf("metal Roughness = %v, want %v", got, want)
+	}
+
+	m = w.Objects[1].GetMaterial()
+	if _, ok := m.Model.(*rtc.BlinnPhongT); !ok {
+		t.Errorf("shiny Model = %T, want *rtc.BlinnPhongT", m.Model)
+	}
+
+	m = w.Objects[2].GetMaterial()
+	if m.Model != nil {
+		t.Errorf("default Model = %T, want nil", m.Model)
+	}
+}
+
+func TestGetMaterial_Absorption(t *testing.T) {
+	const src = `- add: sphere
+  material:
+    transparency: 1
+    absorption-color: [0.2, 0.8, 0.4]
+    absorption-density: 0.5
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+	if got, want := len(w.Objects), 1; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+
+	m := w.Objects[0].GetMaterial()
+	if got, want := m.AbsorptionColor, rtc.Color(0.2, 0.8, 0.4); !got.Equal(want) {
+		t.Errorf("AbsorptionColor = %v, want %v", got, want)
+	}
+	if got, want := m.AbsorptionDensity, 0.5; got != want {
+		t.Errorf("AbsorptionDensity = %v, want %v", got, want)
+	}
+}
+
+func TestAddToWorld_GeometryLight(t *testing.T) {
+	const src = `- add: sphere
+  material:
+    emission: [1, 0.5, 0.25]
+  usteps: 2
+  vsteps: 3
+  jitter: false
+- add: cube
+  material:
+    emission: [1, 1, 1]
+- add: sphere
+  usteps: 2
+- add: group
+  material:
+    emission: [0, 1, 0]
+  children:
+    - add: quad
+      usteps: 2
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+	if got, want := len(w.Objects), 4; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+	// The sphere without emission is not a light.
+	if got, want := len(w.GeometryLights), 2; got != want {
+		t.Fatalf("len(w.GeometryLights) = %v, want %v", got, want)
+	}
+
+	light := w.GeometryLights[0]
+	if light.Object != w.Objects[0] {
+		t.Errorf("light.Object = %v, want %v", light.Object, w.Objects[0])
+	}
+	if light.USteps != 2 || light.VSteps != 3 || light.Jitter {
+		t.Errorf("light = %+v, want 2x3 samples without jitter", light)
+	}
+	if got, want := light.Object.GetMaterial().Emission, rtc.Color(1, 0.5, 0.25); !got.Equal(want) {
+		t.Errorf("Emission = %v, want %v", got, want)
+	}
+
+	// The quad inherits the emission of its group.
+	light = w.GeometryLights[1]
+	if got, want := light.Object, w.Objects[3].(*rtc.GroupT).Children[0]; got != want {
+		t.Errorf("GeometryLights[1].Object = %v, want %v", got, want)
+	}
+	if got, want := light.Object.GetMaterial().Emission, rtc.Color(0, 1, 0); !got.Equal(want) {
+		t.Errorf("inherited Emission = %v, want %v", got, want)
+	}
+}
+
+func TestAddToWorld_Torus(t *testing.T) {
+	const src = `- add: torus
+  major-radius: 2
+  minor-radius: 0.5
+- add: torus
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+	if got, want := len(w.Objects), 2; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		major float64
+		minor float64
+	}{
+		{major: 2, minor: 0.5},
+		{major: 1, minor: 0.25},
+	}
+
+	for i, tt := range tests {
+		torus, ok := w.Objects[i].(*rtc.TorusT)
+		if !ok {
+			t.Fatalf("w.Objects[%v] = %T, want *rtc.TorusT", i, w.Objects[i])
+		}
+		if torus.MajorRadius != tt.major || torus.MinorRadius != tt.minor {
+			t.Errorf("w.Objects[%v] radii = (%v,%v), want (%v,%v)", i, torus.MajorRadius, torus.MinorRadius, tt.major, tt.minor)
+		}
+	}
+}
+
+func TestAddToWorld_PlanarPrimitives(t *testing.T) {
+	const src = `- add: disk
+  radius: 2
+- add: annulus
+  inner-radius: 1
+  outer-radius: 3
+- add: quad
+  corner: [0, 5, 0]
+  uvec: [1, 0, 0]
+  vvec: [0, 0, 2]
+  material:
+    emission: [1, 1, 1]
+  usteps: 2
+  vsteps: 2
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+	if got, want := len(w.Objects), 3; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+
+	disk, ok := w.Objects[0].(*rtc.DiskT)
+	if !ok {
+		t.Fatalf("w.Objects[0] = %T, want *rtc.DiskT", w.Objects[0])
+	}
+	if got, want := disk.Radius, 2.0; got != want {
+		t.Errorf("disk.Radius = %v, want %v", got, want)
+	}
+
+	annulus, ok := w.Objects[1].(*rtc.AnnulusT)
+	if !ok {
+		t.Fatalf("w.Objects[1] = %T, want *rtc.AnnulusT", w.Objects[1])
+	}
+	if annulus.InnerRadius != 1 || annulus.OuterRadius != 3 {
+		t.Errorf("annulus radii = (%v,%v), want (1,3)", annulus.InnerRadius, annulus.OuterRadius)
+	}
+
+	quad, ok := w.Objects[2].(*rtc.QuadT)
+	if !ok {
+		t.Fatalf("w.Objects[2] = %T, want *rtc.QuadT", w.Objects[2])
+	}
+	if got, want := quad.Bounds().Max, rtc.Point(1, 5, 2); !got.Equal(want) {
+		t.Errorf("quad.Bounds.Max = %v, want %v", got, want)
+	}
+
+	if got, want := len(w.GeometryLights), 1; got != want {
+		t.Fatalf("len(w.GeometryLights) = %v, want %v", got, want)
+	}
+	if w.GeometryLights[0].Object != quad {
+		t.Errorf("GeometryLights[0].Object = %v, want %v", w.GeometryLights[0].Object, quad)
+	}
+}
+
+func TestAddToWorld_Blob(t *testing.T) {
+	const src = `- add: blob
+  threshold: 0.6
+  components:
+    - center: [-0.5, 0, 0]
+      radius: 1.5
+      strength: 2
+    - center: [0.5, 0, 0]
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+	if got, want := len(w.Objects), 1; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+
+	blob, ok := w.Objects[0].(*rtc.BlobT)
+	if !ok {
+		t.Fatalf("w.Objects[0] = %T, want *rtc.BlobT", w.Objects[0])
+	}
+	if got, want := blob.Threshold, 0.6; got != want {
+		t.Errorf("blob.Threshold = %v, want %v", got, want)
+	}
+
+	want := []rtc.BlobComponent{
+		{Center: rtc.Point(-0.5, 0, 0), Radius: 1.5, Strength: 2},
+		{Center: rtc.Point(0.5, 0, 0), Radius: 1, Strength: 1},
+	}
+	if len(blob.Components) != len(want) {
+		t.Fatalf("blob.Components = %+v, want %+v", blob.Components, want)
+	}
+	for i, w := range want {
+		got := blob.Components[i]
+		if !got.Center.Equal(w.Center) || got.Radius != w.Radius || got.Strength != w.Strength {
+			t.Errorf("blob.Components[%v] = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestAddToWorld_GroupMaterial(t *testing.T) {
+	const src = `- add: group
+  material:
+    color: [1, 0, 0]
+  transform:
+    - [translate, 0, 1, 0]
+  children:
+    - add: sphere
+    - add: cube
+      material:
+        color: [0, 0, 1]
+    - add: group
+      children:
+        - add: sphere
+          transform:
+            - [scale, 0.5, 0.5, 0.5]
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+	if got, want := len(w.Objects), 1; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+
+	g, ok := w.Objects[0].(*rtc.GroupT)
+	if !ok {
+		t.Fatalf("w.Objects[0] = %T, want *rtc.GroupT", w.Objects[0])
+	}
+	if got, want := g.Transform, rtc.Translation(0, 1, 0); !got.Equal(want) {
+		t.Errorf("group transform = %v, want %v", got, want)
+	}
+	if got, want := len(g.Children), 3; got != want {
+		t.Fatalf("len(g.Children) = %v, want %v", got, want)
+	}
+	inner, ok := g.Children[2].(*rtc.GroupT)
+	if !ok || len(inner.Children) != 1 {
+		t.Fatalf("g.Children[2] = %v, want group with one child", g.Children[2])
+	}
+
+	tests := []struct {
+		name   string
+		object rtc.Object
+		want   rtc.Tuple
+	}{
+		{name: "sphere", object: g.Children[0], want: rtc.Color(1, 0, 0)},
+		{name: "cube with own material", object: g.Children[1], want: rtc.Color(0, 0, 1)},
+		{name: "nested sphere", object: inner.Children[0], want: rtc.Color(1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.object.GetMaterial().Color; !got.Equal(tt.want) {
+				t.Errorf("GetMaterial().Color = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddToWorld_CylinderAndCone(t *testing.T) {
+	const src = `- add: cylinder
+  minimum: 0
+  maximum: 2
+  bottom-radius: 1
+  top-radius: 0.5
+  bottom-closed: true
+  start-angle: 0
+  end-angle: 3.14159
+- add: cone
+  minimum: -1
+  maximum: 1
+  closed: true
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+	if got, want := len(w.Objects), 2; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+
+	cyl, ok := w.Objects[0].(*rtc.CylinderT)
+	if !ok {
+		t.Fatalf("w.Objects[0] = %T, want *rtc.CylinderT", w.Objects[0])
+	}
+	if cyl.Minimum != 0 || cyl.Maximum != 2 || cyl.Closed || !cyl.BottomClosed || cyl.TopClosed {
+		t.Errorf("cylinder = %+v, want from 0 to 2 with a bottom cap", *cyl)
+	}
+	if cyl.BottomRadius != 1 || cyl.TopRadius != 0.5 || cyl.StartAngle != 0 || cyl.EndAngle != 3.14159 {
+		t.Errorf("cylinder = %+v, want radii 1 and 0.5 with a half sweep", *cyl)
+	}
+
+	cone, ok := w.Objects[1].(*rtc.ConeT)
+	if !ok {
+		t.Fatalf("w.Objects[1] = %T, want *rtc.ConeT", w.Objects[1])
+	}
+	if cone.Minimum != -1 || cone.Maximum != 1 || !cone.Closed || cone.BottomRadius != 0 || cone.TopRadius != 0 || cone.EndAngle != 2*math.Pi {
+		t.Errorf("cone = %+v, want unit cone from -1 to 1 with caps", *cone)
+	}
+}
+
+func TestAddToWorld_FogAndVolume(t *testing.T) {
+	const src = `- add: fog
+  mode: exponential
+  color: [0.5, 0.6, 0.7]
+  density: 0.25
+- add: cube
+  material:
+    volume: true
+    volume-scattering: [0.1, 0.2, 0.3]
+    volume-absorption: [0.01, 0.02, 0.03]
+    volume-anisotropy: 0.6
+`
+
+	y, err := Parse(bytes.NewBufferString(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := rtc.World()
+	y.AddToWorld(w)
+
+	if w.Fog == nil {
+		t.Fatal("w.Fog = nil, want fog")
+	}
+	if got, want := w.Fog.Mode, rtc.ExponentialFogMode; got != want {
+		t.Errorf("Fog.Mode = %v, want %v", got, want)
+	}
+	if got, want := w.Fog.Color, rtc.Color(0.5, 0.6, 0.7); !got.Equal(want) {
+		t.Errorf("Fog.Color = %v, want %v", got, want)
+	}
+	if got, want := w.Fog.Density, 0.25; got != want {
+		t.Errorf("Fog.Density = %v, want %v", got, want)
+	}
+
+	if got, want := len(w.Objects), 1; got != want {
+		t.Fatalf("len(w.Objects) = %v, want %v", got, want)
+	}
+	m := w.Objects[0].GetMaterial()
+	if !m.Volume {
+		t.Error("Volume = false, want true")
+	}
+	if got, want := m.VolumeScattering, rtc.Color(0.1, 0.2, 0.3); !got.Equal(want) {
+		t.Errorf("VolumeScattering = %v, want %v", got, want)
+	}
+	if got, want := m.VolumeAbsorption, rtc.Color(0.01, 0.02, 0.03); !got.Equal(want) {
+		t.Errorf("VolumeAbsorption = %v, want %v", got, want)
+	}
+	if got, want := m.VolumeAnisotropy, 0.6; got != want {
+		t.Errorf("VolumeAnisotropy = %v, want %v", got, want)
+	}
+}
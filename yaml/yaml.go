@@ -25,10 +25,57 @@ type Item struct {
 	To     []float64 `json:"to,omitempty"`
 	Up     []float64 `json:"up,omitempty"`
 
+	Projection        *string  `json:"projection,omitempty"`
+	OrthographicWidth *float64 `json:"orthographic-width,omitempty"`
+
 	// light
 	At        []float64 `json:"at,omitempty"`
 	Intensity []float64 `json:"intensity,omitempty"`
 
+	// fog
+	Mode    *string   `json:"mode,omitempty"`
+	Color   []float64 `json:"color,omitempty"`
+	Start   *float64  `json:"start,omitempty"`
+	End     *float64  `json:"end,omitempty"`
+	Density *float64  `json:"density,omitempty"`
+
+	// torus
+	MajorRadius *float64 `json:"major-radius,omitempty"`
+	MinorRadius *float64 `json:"minor-radius,omitempty"`
+
+	// disk and annulus
+	Radius      *float64 `json:"radius,omitempty"`
+	InnerRadius *float64 `json:"inner-radius,omitempty"`
+	OuterRadius *float64 `json:"outer-radius,omitempty"`
+
+	// quad
+	Corner []float64 `json:"corner,omitempty"`
+	UVec   []float64 `json:"uvec,omitempty"`
+	VVec   []float64 `json:"vvec,omitempty"`
+
+	// cylinder and cone
+	Minimum      *float64 `json:"minimum,omitempty"`
+	Maximum      *float64 `json:"maximum,omitempty"`
+	Closed       *bool    `json:"closed,omitempty"`
+	BottomRadius *float64 `json:"bottom-radius,omitempty"`
+	TopRadius    *float64 `json:"top-radius,omitempty"`
+	BottomClosed *bool    `json:"bottom-closed,omitempty"`
+	TopClosed    *bool    `json:"top-closed,omitempty"`
+	StartAngle   *float64 `json:"start-angle,omitempty"`
+	EndAngle     *float64 `json:"end-angle,omitempty"`
+
+	// blob
+	Threshold  *float64             `json:"threshold,omitempty"`
+	Components []*YAMLBlobComponent `json:"components,omitempty"`
+
+	// group
+	Children []*Item `json:"children,omitempty"`
+
+	// geometry light (any emissive object)
+	USteps *int  `json:"usteps,omitempty"`
+	VSteps *int  `json:"vsteps,omitempty"`
+	Jitter *bool `json:"jitter,omitempty"`
+
 	// define
 	Extend   *string         `json:"extend,omitempty"`
 	RawValue json.RawMessage `json:"value,omitempty"`
@@ -46,14 +93,31 @@ type Item struct {
 type YAMLMaterial struct {
 	NamedItem *string `json:"-"`
 
-	Color           []float64 `json:"color,omitempty"`
-	Diffuse         *float64  `json:"diffuse,omitempty"`
-	Ambient         *float64  `json:"ambient,omitempty"`
-	Specular        *float64  `json:"specular,omitempty"`
-	Shininess       *float64  `json:"shininess,omitempty"`
-	Reflective      *float64  `json:"reflective,omitempty"`
-	Transparency    *float64  `json:"transparency,omitempty"`
-	RefractiveIndex *float64  `json:"refractive-index,omitempty"`
+	Color             []float64 `json:"color,omitempty"`
+	Diffuse           *float64  `json:"diffuse,omitempty"`
+	Ambient           *float64  `json:"ambient,omitempty"`
+	Specular          *float64  `json:"specular,omitempty"`
+	Shininess         *float64  `json:"shininess,omitempty"`
+	Reflective        *float64  `json:"reflective,omitempty"`
+	Transparency      *float64  `json:"transparency,omitempty"`
+	RefractiveIndex   *float64  `json:"refractive-index,omitempty"`
+	AbsorptionColor   []float64 `json:"absorption-color,omitempty"`
+	AbsorptionDensity *float64  `json:"absorption-density,omitempty"`
+	Volume            *bool     `json:"volume,omitempty"`
+	VolumeScattering  []float64 `json:"volume-scattering,omitempty"`
+	VolumeAbsorption  []float64 `json:"volume-absorption,omitempty"`
+	VolumeAnisotropy  *float64  `json:"volume-anisotropy,omitempty"`
+	Emission          []float64 `json:"emission,omitempty"`
+	Model             *string   `json:"model,omitempty"`
+	Metallic          *float64  `json:"metallic,omitempty"`
+	Roughness         *float64  `json:"roughness,omitempty"`
+}
+
+// YAMLBlobComponent represents a single component of a blob.
+type YAMLBlobComponent struct {
+	Center   []float64 `json:"center,omitempty"`
+	Radius   *float64  `json:"radius,omitempty"`
+	Strength *float64  `json:"strength,omitempty"`
 }
 
 // YAMLTransform is either a named DefinedItems value or a Transform.
@@ -78,6 +142,12 @@ func (i Item) String() string {
 		}
 		return p
 	}
+	addBool := func(p []string, s *bool, n string) []string {
+		if s != nil {
+			p = append(p, fmt.Sprintf("%v:B(%v)", n, *s))
+		}
+		return p
+	}
 	addFloatArray := func(p []string, s []float64, n string) []string {
 		if len(s) > 0 {
 			p = append(p, fmt.Sprintf("%v:%#v", n, s))
@@ -110,9 +180,46 @@ func (i Item) String() string {
 		p2 = addFloat(p2, v.Reflective, "Reflective")
 		p2 = addFloat(p2, v.Transparency, "Transparency")
 		p2 = addFloat(p2, v.RefractiveIndex, "RefractiveIndex")
+		p2 = addFloatArray(p2, v.AbsorptionColor, "AbsorptionColor")
+		p2 = addFloat(p2, v.AbsorptionDensity, "AbsorptionDensity")
+		p2 = addBool(p2, v.Volume, "Volume")
+		p2 = addFloatArray(p2, v.VolumeScattering, "VolumeScattering")
+		p2 = addFloatArray(p2, v.VolumeAbsorption, "VolumeAbsorption")
+		p2 = addFloat(p2, v.VolumeAnisotropy, "VolumeAnisotropy")
+		p2 = addFloatArray(p2, v.Emission, "Emission")
+		p2 = addString(p2, v.Model, "Model")
+		p2 = addFloat(p2, v.Metallic, "Metallic")
+		p2 = addFloat(p2, v.Roughness, "Roughness")
 		p = append(p, fmt.Sprintf("%v:&YAMLMaterial{%v}", n, strings.Join(p2, ",")))
 		return p
 	}
+	addItems := func(p []string, vs []*Item, n string) []string {
+		if len(vs) == 0 {
+			return p
+		}
+		var p2 []string
+		for _, v := range vs {
+			p2 = append(p2, v.String())
+		}
+		p = append(p, fmt.Sprintf("%v:[]*Item{%v}", n, strings.Join(p2, ",")))
+		return p
+	}
+
+	addYAMLBlobComponents := func(p []string, vs []*YAMLBlobComponent, n string) []string {
+		if len(vs) == 0 {
+			return p
+		}
+		var p2 []string
+		for _, v := range vs {
+			var items []string
+			items = addFloatArray(items, v.Center, "Center")
+			items = addFloat(items, v.Radius, "Radius")
+			items = addFloat(items, v.Strength, "Strength")
+			p2 = append(p2, strings.Join(items, ","))
+		}
+		p = append(p, fmt.Sprintf("%v:[]*YAMLBlobComponent{{%v}}", n, strings.Join(p2, "},{")))
+		return p
+	}
 	addYAMLTransforms := func(p []string, vs []*YAMLTransform, n string) []string {
 		if len(vs) == 0 {
 			return p
@@ -137,8 +244,38 @@ func (i Item) String() string {
 	parts = addFloatArray(parts, i.From, "From")
 	parts = addFloatArray(parts, i.To, "To")
 	parts = addFloatArray(parts, i.Up, "Up")
+	parts = addString(parts, i.Projection, "Projection")
+	parts = addFloat(parts, i.OrthographicWidth, "OrthographicWidth")
 	parts = addFloatArray(parts, i.At, "At")
 	parts = addFloatArray(parts, i.Intensity, "Intensity")
+	parts = addString(parts, i.Mode, "Mode")
+	parts = addFloatArray(parts, i.Color, "Color")
+	parts = addFloat(parts, i.Start, "Start")
+	parts = addFloat(parts, i.End, "End")
+	parts = addFloat(parts, i.Density, "Density")
+	parts = addFloat(parts, i.MajorRadius, "MajorRadius")
+	parts = addFloat(parts, i.MinorRadius, "MinorRadius")
+	parts = addFloat(parts, i.Radius, "Radius")
+	parts = addFloat(parts, i.InnerRadius, "InnerRadius")
+	parts = addFloat(parts, i.OuterRadius, "OuterRadius")
+	parts = addFloatArray(parts, i.Corner, "Corner")
+	parts = addFloatArray(parts, i.UVec, "UVec")
+	parts = addFloatArray(parts, i.VVec, "VVec")
+	parts = addFloat(parts, i.Minimum, "Minimum")
+	parts = addFloat(parts, i.Maximum, "Maximum")
+	parts = addBool(parts, i.Closed, "Closed")
+	parts = addFloat(parts, i.BottomRadius, "BottomRadius")
+	parts = addFloat(parts, i.TopRadius, "TopRadius")
+	parts = addBool(parts, i.BottomClosed, "BottomClosed")
+	parts = addBool(parts, i.TopClosed, "TopClosed")
+	parts = addFloat(parts, i.StartAngle, "StartAngle")
+	parts = addFloat(parts, i.EndAngle, "EndAngle")
+	parts = addFloat(parts, i.Threshold, "Threshold")
+	parts = addYAMLBlobComponents(parts, i.Components, "Components")
+	parts = addItems(parts, i.Children, "Children")
+	parts = addInt(parts, i.USteps, "USteps")
+	parts = addInt(parts, i.VSteps, "VSteps")
+	parts = addBool(parts, i.Jitter, "Jitter")
 	parts = addString(parts, i.Extend, "Extend")
 	parts = addRaw(parts, i.RawValue, "RawValue")
 	parts = addRaw(parts, i.RawMaterial, "RawMaterial")